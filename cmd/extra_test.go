@@ -52,6 +52,11 @@ func TestExecuteExitCodes(t *testing.T) {
 		t.Fatalf("expected ExitViolation, got %d", code)
 	}
 
+	os.Args = []string{"syl-wordcount", file, "--detect-similar", "--similarity-threshold", "1.5"}
+	if code := Execute(); code != ExitArg {
+		t.Fatalf("expected ExitArg for out-of-range threshold, got %d", code)
+	}
+
 	os.Args = []string{"syl-wordcount", filepath.Join(tmp, "missing.txt")}
 	if code := Execute(); code != ExitInput {
 		t.Fatalf("expected ExitInput, got %d", code)
@@ -9,11 +9,15 @@ func rootLongHelp() string {
 两种使用方式（AI 首选）：
 1. 统计字数（默认模式）
    - 命令：syl-wordcount <path...>
-   - 输出：file_stats 事件（chars / lines / max_line_width / hash）
+   - 输出：file_stats 事件（chars / lines / max_line_width / sentences / paragraphs / readability / reading_time_seconds / hash / languages / zh_variant）
 2. 规则校验（check 模式）
    - 命令：syl-wordcount check <path...> --config rules.yaml
    - 或：仅用 SYL_WC_* 环境变量
    - 输出：violation/error 事件（默认隐藏 pass；可用 --all 输出 pass）
+3. 脱敏（redact 模式）
+   - 命令：syl-wordcount redact <path...> --config rules.yaml --out-dir /tmp/redacted
+   - 用 forbidden_patterns 与 secrets / pii 规则把命中替换为 [REDACTED:rule_id]，保留原编码与换行符
+   - 输出：redaction / redacted_file 事件；单个文件不传 --out-dir 时内容写到标准输出
 
 输入与扫描：
 - 支持多个文件、多个目录、文件+目录混合
@@ -27,6 +31,10 @@ func rootLongHelp() string {
 - file_stats
 - pass
 - violation
+- fix（仅 check --fix）
+- redaction / redacted_file（仅 redact）
+- similar_group（仅统计模式 --detect-similar：exact 为 SHA256 完全相同，near 为 MinHash 近似重复，带两两相似度 pairs）
+- warning（不影响退出码，如章节规则未匹配）
 - error
 - summary
 
@@ -57,6 +65,9 @@ func rootExampleHelp() string {
   # 方式 1：统计字数（目录）
   syl-wordcount /path/to/docs
 
+  # 统计并找出完全相同/近似重复的文件
+  syl-wordcount /path/to/docs --detect-similar --similarity-threshold 0.85
+
   # 方式 1：统计字数（目录 + 文件）
   syl-wordcount /path/to/docs /path/to/README.md
 
@@ -71,6 +82,9 @@ func rootExampleHelp() string {
 
   # 方式 2：校验时输出 pass + violation + error
   syl-wordcount check /path/to/docs --config /path/to/rules.yaml --all
+
+  # 方式 3：脱敏后再交给模型
+  SYL_WC_SECRETS=true SYL_WC_PII=true syl-wordcount redact /path/to/a.md > a.redacted.md
 `)
 }
 
@@ -88,8 +102,15 @@ func checkLongHelp() string {
 - 全局规则 + section_rules 并行执行，结果会同时输出
 
 section_rules 说明：
-- 字段：heading_contains + rules
-- 匹配方式：标题文本包含 heading_contains 即命中
+- 字段：标题条件 + rules，条件之间为“且”关系
+  - heading_contains：标题包含该文本
+  - heading_equals：标题与该文本完全相同
+  - heading_regex：标题匹配该正则
+  - level / min_level / max_level：标题级别（1~6）
+  - files：只对匹配 glob 的文件生效（匹配绝对路径、相对路径；不含 / 时也匹配文件名）
+  - match：all（默认，命中全部章节）/ first（只取第一个命中的章节）
+- 至少要有一个标题或级别条件
+- 文件里有标题、但某条规则一个也没匹配到时，输出 warning 事件（不影响退出码）
 - 章节边界：从命中标题起，到下一个同级或更高层级标题前
 - 作用域标记：违规事件中 scope=section
 
@@ -129,11 +150,14 @@ section_rules 说明：
    - 环境变量：SYL_WC_MAX_CONSECUTIVE_BLANK_LINES
 12. forbidden_patterns
    - 含义：禁止出现的正则模式（命中即违规）
+   - 选项：literal（字面匹配）/ whole_word（词边界）/ max_count（最多命中次数）/ allow（例外正则）/ message / id（自定义 rule_id）
+   - 跨行：multiline（^/$ 匹配每行）/ dotall（. 匹配换行）；跨行命中带 end_line/end_column，snippet 列出涉及的所有行
    - 环境变量：
      - SYL_WC_FORBIDDEN_PATTERNS（大小写敏感，逗号分隔）
      - SYL_WC_FORBIDDEN_PATTERNS_I（大小写不敏感，逗号分隔）
 13. required_patterns
    - 含义：必须出现的正则模式（全部都要命中）
+   - 选项：同 forbidden_patterns，min_count 为最少命中次数
    - 环境变量：
      - SYL_WC_REQUIRED_PATTERNS（大小写敏感，逗号分隔）
      - SYL_WC_REQUIRED_PATTERNS_I（大小写不敏感，逗号分隔）
@@ -146,17 +170,124 @@ section_rules 说明：
 16. section_rules
    - 含义：章节级规则列表（每条可独立配置）
    - 环境变量：SYL_WC_SECTION_RULES（JSON 数组）
+17. no_zero_width_chars
+   - 含义：禁止零宽字符（U+200B/U+200C/U+200D/U+2060/U+FEFF，文件开头的 BOM 除外）
+   - 环境变量：SYL_WC_NO_ZERO_WIDTH_CHARS
+18. no_bidi_controls
+   - 含义：禁止双向文本控制字符（U+202A~U+202E、U+2066~U+2069、U+200E/U+200F/U+061C）
+   - 环境变量：SYL_WC_NO_BIDI_CONTROLS
+19. no_nbsp
+   - 含义：禁止不间断空格（U+00A0/U+2007/U+202F）
+   - 环境变量：SYL_WC_NO_NBSP
+20. require_nfc
+   - 含义：要求文本为 NFC 规范化形式（如 e + U+0301 应写成 é）
+   - 环境变量：SYL_WC_REQUIRE_NFC
+21. forbidden_codepoints
+   - 含义：禁止的码位或码位范围列表（如 U+200B、U+2000-U+200F）
+   - 环境变量：SYL_WC_FORBIDDEN_CODEPOINTS（逗号分隔）
+22. zh_typography
+   - 含义：中文排版规则组，子项可单独开关：
+     - cjk_latin_space：中文与英文/数字之间要有空格（可自动修复）
+     - fullwidth_punctuation：中文句子里使用全角标点（可自动修复）
+     - no_halfwidth_comma_after_han：中文字符后禁止半角逗号（可自动修复）
+     - no_duplicate_punctuation：禁止重复标点（如 。。）
+   - 环境变量：SYL_WC_ZH_TYPOGRAPHY（逗号分隔的子项名，all 表示全部）
+23. chinese_variant
+   - 含义：期望的中文书写体系 hans/hant，逐字标出混入的另一体系用字并给出对应写法（唯一对应时可自动修复）
+   - 环境变量：SYL_WC_CHINESE_VARIANT
+24. allowed_languages
+   - 含义：允许的主要语言列表（zh/ja/ko/en/de/fr/es/it/pt/nl/ru/uk 等），放在 section_rules 中可逐章节检查双语文档
+   - 环境变量：SYL_WC_ALLOWED_LANGUAGES（逗号分隔）
+25. max_sentence_chars
+   - 含义：单句字符数上限（按 。！？ 与 .!? 断句，识别常见英文缩写），违规指向句首
+   - 环境变量：SYL_WC_MAX_SENTENCE_CHARS
+26. max_paragraph_chars / max_paragraph_lines
+   - 含义：单个段落（空行分隔，不含标题与代码块）的字符数/行数上限，违规指向段首
+   - 环境变量：SYL_WC_MAX_PARAGRAPH_CHARS / SYL_WC_MAX_PARAGRAPH_LINES
+27. min_readability / max_grade_level
+   - 含义：可读性评分下限 / 阅读年级上限（英文用 Flesch / Flesch–Kincaid，中文用句长指数，其他语言不评分）
+   - 环境变量：SYL_WC_MIN_READABILITY / SYL_WC_MAX_GRADE_LEVEL
+28. max_reading_time / max_speaking_time
+   - 含义：预计阅读/朗读时长上限（纯数字为秒，也可写 90s、5m）；速度由 reading_speed 配置（默认 230 词/分、400 字/分，朗读 150 词/分、250 字/分）
+   - 环境变量：SYL_WC_MAX_READING_TIME / SYL_WC_MAX_SPEAKING_TIME；速度：SYL_WC_READING_WPM / SYL_WC_READING_CPM / SYL_WC_SPEAKING_WPM / SYL_WC_SPEAKING_CPM
+29. required_sections
+   - 含义：必需的 Markdown 章节（标题全等匹配），可选 ordered（按顺序）、level（级别）、files（glob）；缺失时指向建议插入位置
+   - 环境变量：SYL_WC_REQUIRED_SECTIONS（逗号分隔）/ SYL_WC_REQUIRED_SECTIONS_ORDERED
+30. forbidden_sections
+   - 含义：禁止出现的章节标题（全等匹配）
+   - 环境变量：SYL_WC_FORBIDDEN_SECTIONS（逗号分隔）
+31. max_heading_depth / no_skipped_heading_levels / single_h1 / unique_headings
+   - 含义：标题最大层级 / 禁止跳级（如 H2 后直接 H4）/ 只允许一个 H1 / 标题不重复
+   - 环境变量：SYL_WC_MAX_HEADING_DEPTH / SYL_WC_NO_SKIPPED_HEADING_LEVELS / SYL_WC_SINGLE_H1 / SYL_WC_UNIQUE_HEADINGS
+32. check_local_links
+   - 含义：Markdown 链接/图片/引用定义的本地目标必须存在（相对文档解析），#锚点必须对应目标文档标题；外部链接只检查格式，不联网
+   - actual 为链接目标；limit：exists / anchor_exists / valid_url / defined
+   - 环境变量：SYL_WC_CHECK_LOCAL_LINKS
+33. require_image_alt / forbid_bare_urls / forbid_empty_link_text / forbid_generic_link_text
+   - 含义：图片必须有替代文本 / 禁止裸 URL（--fix 改写为 <url>）/ 链接文本不能为空 / 链接文本不能是“点击这里”“click here”等泛化词（generic_link_texts 可替换词表）；仅 Markdown，可用于章节
+   - 环境变量：SYL_WC_REQUIRE_IMAGE_ALT / SYL_WC_FORBID_BARE_URLS / SYL_WC_FORBID_EMPTY_LINK_TEXT / SYL_WC_FORBID_GENERIC_LINK_TEXT / SYL_WC_GENERIC_LINK_TEXTS
+34. terminology
+   - 含义：术语表，{use, avoid, exceptions, case_sensitive} 条目，命中 avoid 写法报违规并给出 use（--fix 直接替换）；拉丁字母按词边界匹配，默认忽略大小写；可用于章节
+   - 配置：可直接写条目列表，或 {file, case_sensitive, terms}；file 为 YAML 或 CSV（use,avoid,exceptions,case_sensitive，多值用 | 分隔）
+   - 环境变量：SYL_WC_TERMINOLOGY（JSON 数组）/ SYL_WC_TERMINOLOGY_FILE / SYL_WC_TERMINOLOGY_CASE_SENSITIVE
+35. forbidden_words_file / required_words_file
+   - 含义：纯文本词表（每行一个词，# 为注释），Aho–Corasick 一次扫描匹配全部词；禁用词每次命中报 forbidden_word，必需词缺一个报 required_word
+   - 选项：可只写路径，或 {file, case_sensitive（默认 true）, whole_word}
+   - 环境变量：SYL_WC_FORBIDDEN_WORDS_FILE / _CASE_SENSITIVE / _WHOLE_WORD，SYL_WC_REQUIRED_WORDS_FILE / _CASE_SENSITIVE / _WHOLE_WORD
+36. no_duplicate_lines / no_duplicate_paragraphs / duplicate_content
+   - 含义：文件内重复的行 / 重复的段落，以及跨文件重复的段落；比较时去掉所有空白，空行、标题、围栏代码块与纯符号行不参与
+   - 配置：true，或 {min_chars}（去掉空白后的最少字符数，默认行 20、段落 40）
+   - duplicate_content：每组重复段落报一条违规（scope 为 corpus），locations 列出所有位置（path/line/end_line），至少涉及两个文件
+   - 环境变量：SYL_WC_NO_DUPLICATE_LINES / SYL_WC_NO_DUPLICATE_PARAGRAPHS / SYL_WC_DUPLICATE_CONTENT，各自加 _MIN_CHARS
+37. max_total_chars / max_total_files / max_files_per_directory / corpus_budgets
+   - 含义：语料级总量，所有文件处理完后检查整批输入：总字符数、总文件数、单目录文件数、按 glob 分组的总量（corpus_budgets: [{files, max_total_chars, max_total_files}]，规则 ID 为 corpus_budget.max_total_chars / corpus_budget.max_total_files）
+   - 输出：scope 为 corpus，top_files 列出字符数最多的 10 个文件；按目录的违规 path 为目录，其余 path 为空
+   - 环境变量：SYL_WC_MAX_TOTAL_CHARS / SYL_WC_MAX_TOTAL_FILES / SYL_WC_MAX_FILES_PER_DIRECTORY / SYL_WC_CORPUS_BUDGETS（JSON 数组）
+38. filename_pattern / max_filename_length / max_path_depth / forbid_whitespace_in_names / forbid_non_ascii_names / required_files
+   - 含义：路径规则，只看名称不读内容；文件名须匹配正则、文件名长度、相对输入目录的层级（文件名算一层）、文件名与目录名不含空白 / 非 ASCII 字符
+   - required_files: [{dirs, files}]：匹配 dirs（glob）的每个目录都要包含 files 中的文件，每缺一个报一条，path 为目录
+   - 输出：scope 为 path，line 为 0；跳过的二进制/超大文件同样检查
+   - 环境变量：SYL_WC_FILENAME_PATTERN / SYL_WC_MAX_FILENAME_LENGTH / SYL_WC_MAX_PATH_DEPTH / SYL_WC_FORBID_WHITESPACE_IN_NAMES / SYL_WC_FORBID_NON_ASCII_NAMES / SYL_WC_REQUIRED_FILES（JSON 数组）
+39. secrets / pii
+   - 含义：内置敏感信息检测，规则 ID 带组名前缀。secrets：private_key / aws_access_key / github_token / slack_token / google_api_key / stripe_key / llm_api_key / jwt / high_entropy；pii：cn_id_card（校验码）/ bank_card（Luhn）/ cn_mobile / email
+   - 配置：true，或 {exclude, min_entropy, min_length}（后两项只用于 secrets.high_entropy，默认 4.0 / 24）
+   - 输出：actual 与 snippet 中的命中已遮盖，limit 为 none；抑制指令可写组名（pii）或单条（secrets.jwt）
+   - 环境变量：SYL_WC_SECRETS / SYL_WC_PII，SYL_WC_SECRETS_EXCLUDE / SYL_WC_PII_EXCLUDE，SYL_WC_SECRETS_MIN_ENTROPY / SYL_WC_SECRETS_MIN_LENGTH
+
+章节识别：
+- 支持 ATX（## 标题）与 setext（=== / --- 下划线）标题，忽略围栏代码块、缩进代码块与 front matter
+- markdown_html_headings: true（SYL_WC_MARKDOWN_HTML_HEADINGS）时识别单行 <h1>~<h6>
+- 按扩展名自动选择切分器：.rst（下划线/上下划线标题）、.adoc（= 标题）、.org（* 标题），其余按 Markdown
+- section_syntax 覆盖：字符串对所有文件生效，映射按扩展名指定（如 .txt: custom）；环境变量 SYL_WC_SECTION_SYNTAX（rst 或 .txt=custom）
+- custom 语法逐行匹配 section_heading_pattern（命名分组 title/level，缺省识别“1.2 标题”）；环境变量 SYL_WC_SECTION_HEADING_PATTERN
+- 统计模式加 --sections 可在 file_stats 中查看章节及行范围
 
 section_rules.rules 可用子规则：
 - min_chars / max_chars
 - min_lines / max_lines
 - max_line_width / avg_line_width
+- max_sentence_chars / max_paragraph_chars / max_paragraph_lines
+- min_readability / max_grade_level
+- max_reading_time / max_speaking_time
 - no_trailing_spaces / no_tabs / no_fullwidth_space
+- no_zero_width_chars / no_bidi_controls / no_nbsp / require_nfc / forbidden_codepoints
+- zh_typography / chinese_variant / allowed_languages
+- require_image_alt / forbid_bare_urls / forbid_empty_link_text / forbid_generic_link_text / generic_link_texts
 - max_consecutive_blank_lines
 - forbidden_patterns / required_patterns
 
+自动修复（--fix）：
+- 带 fix 字段的违规可自动修复，加 --fix 后直接写回文件（保留原编码与换行符）
+- 已修复的违规不再输出，改为每个文件一条 fix 事件；summary 带 fixed_count
+
+行内抑制指令：
+- 写在注释或纯文本中：<!-- syl-wc-disable-next-line max_line_width -->、syl-wc-disable-line、syl-wc-disable / syl-wc-enable 区间、syl-wc-disable-file
+- 规则列表用逗号或空白分隔，省略表示所有规则，zh_typography 覆盖整组；文件级违规只能用 disable-file 抑制
+- summary 带 suppressed_count；--report-unused-disables 让没抑制任何违规的指令输出 warning（unused_disable_directive）
+
 注意：
 - check 如果没有任何规则来源，会返回配置错误（退出码 4）
+- 规则在扫描前编译一次，正则等配置错误统一报一次（退出码 4），不会逐文件重复
 - 正则引擎为 Go RE2 语义
 - ignore_patterns 使用 glob 语法（如 **/*.log）
 `)
@@ -180,5 +311,47 @@ func checkExampleHelp() string {
 
   # 4) 全量输出（包含 pass）
   syl-wordcount check /path/to/docs --config /path/to/rules.yaml --all
+
+  # 5) 中文排版检查并自动修复
+  SYL_WC_ZH_TYPOGRAPHY=all syl-wordcount check /path/to/docs --fix
+`)
+}
+
+func redactLongHelp() string {
+	return strings.TrimSpace(`
+脱敏模式：把文本交给模型前，用规则把敏感内容替换成遮盖文本，生成脱敏副本（不修改原文件）。
+
+规则来源与 check 相同（--config 或 SYL_WC_* 环境变量），只用到其中两类：
+- forbidden_patterns（全局）：每次命中都遮盖，规则 ID 为 id（未设置时为 forbidden_pattern）
+- secrets / pii：见 check --help 第 39 项
+两类都没配置时返回配置错误（退出码 4）；其他规则在 redact 中不生效，行内抑制指令也不生效。
+
+输出位置：
+- --out-dir DIR：按输入目录结构写入（输入目录下的 guide/a.md 写到 DIR/guide/a.md，单个输入文件写到 DIR/文件名）；
+  没有命中的文件原样复制，输出路径冲突或会覆盖输入文件时报参数错误（退出码 2）
+- 不传 --out-dir：只能输入单个文件，脱敏内容写到标准输出，NDJSON 事件写到标准错误
+
+遮盖：
+- --mask 模板默认 [REDACTED:{rule_id}]，{rule_id} 替换为命中的规则 ID
+- 重叠的命中只遮盖起点最早（同起点时最长）的一处；命中末尾的换行符保留
+- 写回时保留原编码（utf-8/gbk/gb18030）、换行符与文件权限
+
+事件：
+- redaction：path / rule_id / line / column / end_line / end_column（原文位置，结束列为命中之后的列）/ replacement，不含原文
+- redacted_file：path / status（ok 或 skipped，跳过的文件按输入错误处理）/ output（标准输出时为 -，跳过时为空）/ encoding / line_ending / redactions
+- summary：redaction_count；只要没有输入错误，退出码为 0
+`)
+}
+
+func redactExampleHelp() string {
+	return strings.TrimSpace(`
+  # 1) 目录脱敏到新目录
+  syl-wordcount redact /path/to/docs --config /path/to/rules.yaml --out-dir /tmp/redacted
+
+  # 2) 单个文件脱敏到标准输出（事件在标准错误）
+  SYL_WC_SECRETS=true SYL_WC_PII=true syl-wordcount redact /path/to/a.md > a.redacted.md 2> redact.ndjson
+
+  # 3) 自定义遮盖文本
+  syl-wordcount redact /path/to/a.md --config /path/to/rules.yaml --mask '<{rule_id}>'
 `)
 }
@@ -20,7 +20,16 @@ type commonFlags struct {
 	Jobs        int
 	MaxFileSize string
 	CheckAll    bool
+	Fix         bool
+	Sections    bool
 	ShowVersion bool
+
+	ReportUnusedDisables bool
+	DetectSimilar        bool
+	SimilarityThreshold  float64
+
+	OutDir string
+	Mask   string
 }
 
 func Execute() int {
@@ -65,13 +74,15 @@ func NewRootCmd(stdout, stderr io.Writer) *cobra.Command {
 				_ = cmd.Help()
 				return &ExitError{Code: ExitArg, Msg: "还没传输入路径，至少要给一个文件或目录。示例：syl-wordcount /path/to/input_dir"}
 			}
-			return runMode(stdout, flags, app.ModeStats, args)
+			return runMode(stdout, stderr, flags, app.ModeStats, args)
 		},
 	}
 	root.SetOut(stdout)
 	root.SetErr(stderr)
 	root.CompletionOptions.HiddenDefaultCmd = true
 	bindCommon(root, flags)
+	root.Flags().BoolVar(&flags.Sections, "sections", false, "在 file_stats 中输出识别到的章节及其行范围（排查章节切分）")
+	bindSimilarFlags(root, flags)
 
 	internalStatsCmd := &cobra.Command{
 		Use:           "__stats [paths...]",
@@ -80,9 +91,11 @@ func NewRootCmd(stdout, stderr io.Writer) *cobra.Command {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runMode(stdout, flags, app.ModeStats, args)
+			return runMode(stdout, stderr, flags, app.ModeStats, args)
 		},
 	}
+	internalStatsCmd.Flags().BoolVar(&flags.Sections, "sections", false, "在 file_stats 中输出识别到的章节及其行范围（排查章节切分）")
+	bindSimilarFlags(internalStatsCmd, flags)
 	root.AddCommand(internalStatsCmd)
 
 	checkCmd := &cobra.Command{
@@ -97,12 +110,33 @@ func NewRootCmd(stdout, stderr io.Writer) *cobra.Command {
 				printVersion(stdout)
 				return nil
 			}
-			return runMode(stdout, flags, app.ModeCheck, args)
+			return runMode(stdout, stderr, flags, app.ModeCheck, args)
 		},
 	}
 	checkCmd.Flags().BoolVar(&flags.CheckAll, "all", false, "输出全量结果（包含 pass 事件）")
+	checkCmd.Flags().BoolVar(&flags.Fix, "fix", false, "自动修复可修复的违规并写回文件（如中文排版空格、标点宽度）")
+	checkCmd.Flags().BoolVar(&flags.ReportUnusedDisables, "report-unused-disables", false, "没有抑制任何违规的 syl-wc-disable 指令输出 warning")
 	root.AddCommand(checkCmd)
 
+	redactCmd := &cobra.Command{
+		Use:           "redact [paths...]",
+		Short:         "用禁止模式与 secrets / pii 规则生成脱敏副本",
+		Long:          redactLongHelp(),
+		Example:       redactExampleHelp(),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.ShowVersion {
+				printVersion(stdout)
+				return nil
+			}
+			return runMode(stdout, stderr, flags, app.ModeRedact, args)
+		},
+	}
+	redactCmd.Flags().StringVar(&flags.OutDir, "out-dir", "", "脱敏副本的输出目录（按输入目录结构写入）；不传时只能输入单个文件，内容写到标准输出、事件写到标准错误")
+	redactCmd.Flags().StringVar(&flags.Mask, "mask", app.DefaultRedactMask, "遮盖模板，{rule_id} 替换为命中的规则 ID")
+	root.AddCommand(redactCmd)
+
 	versionCmd := &cobra.Command{
 		Use:   "version",
 		Short: "显示版本信息",
@@ -122,7 +156,17 @@ func bindCommon(cmd *cobra.Command, flags *commonFlags) {
 	cmd.PersistentFlags().BoolVarP(&flags.ShowVersion, "version", "v", false, "显示版本信息")
 }
 
-func runMode(stdout io.Writer, flags *commonFlags, mode app.Mode, args []string) error {
+func bindSimilarFlags(cmd *cobra.Command, flags *commonFlags) {
+	cmd.Flags().BoolVar(&flags.DetectSimilar, "detect-similar", false, "输出内容完全相同（SHA256）与近似重复（MinHash）的文件组（similar_group 事件）")
+	cmd.Flags().Float64Var(&flags.SimilarityThreshold, "similarity-threshold", app.DefaultSimilarityThreshold, "近似重复的相似度阈值（0~1，配合 --detect-similar）")
+}
+
+func runMode(stdout, stderr io.Writer, flags *commonFlags, mode app.Mode, args []string) error {
+	// redact 没有 --out-dir 时脱敏内容占用标准输出，事件改写到标准错误
+	content := io.Writer(nil)
+	if mode == app.ModeRedact && flags.OutDir == "" {
+		content, stdout = stdout, stderr
+	}
 	if len(args) == 0 {
 		msg := "还没传输入路径，至少要给一个文件或目录。示例：syl-wordcount /path/to/input_dir"
 		writeCLIError(stdout, flags.Format, string(mode), args, "arg_missing_paths", "arg", "", msg, ExitArg)
@@ -150,15 +194,22 @@ func runMode(stdout io.Writer, flags *commonFlags, mode app.Mode, args []string)
 		return &ExitError{Code: ExitArg, Msg: msg}
 	}
 	res, err := app.Run(app.Options{
-		Mode:             mode,
-		Paths:            paths,
-		CWD:              cwd,
-		ConfigPath:       flags.Config,
-		Format:           flags.Format,
-		Jobs:             flags.Jobs,
-		MaxFileSizeBytes: maxBytes,
-		Version:          Version,
-		Args:             os.Args[1:],
+		Mode:                 mode,
+		Paths:                paths,
+		CWD:                  cwd,
+		ConfigPath:           flags.Config,
+		Format:               flags.Format,
+		Jobs:                 flags.Jobs,
+		MaxFileSizeBytes:     maxBytes,
+		Fix:                  flags.Fix,
+		Sections:             flags.Sections,
+		ReportUnusedDisables: flags.ReportUnusedDisables,
+		OutDir:               flags.OutDir,
+		Mask:                 flags.Mask,
+		DetectSimilar:        flags.DetectSimilar,
+		SimilarityThreshold:  flags.SimilarityThreshold,
+		Version:              Version,
+		Args:                 os.Args[1:],
 	})
 	if err != nil {
 		switch err.(type) {
@@ -182,6 +233,12 @@ func runMode(stdout io.Writer, flags *commonFlags, mode app.Mode, args []string)
 		msg := fmt.Sprintf("输出结果失败：%v", werr)
 		return &ExitError{Code: ExitInternal, Msg: msg}
 	}
+	if content != nil && res.Redacted != nil {
+		if _, werr := content.Write(res.Redacted); werr != nil {
+			msg := fmt.Sprintf("输出脱敏内容失败：%v", werr)
+			return &ExitError{Code: ExitInternal, Msg: msg}
+		}
+	}
 	code := 0
 	if res.HasInternalErr {
 		code = ExitInternal
@@ -250,7 +307,7 @@ func normalizeArgs(args []string) []string {
 	}
 	first := args[0]
 	switch first {
-	case "stats", "check", "version", "help", "completion", "__stats":
+	case "stats", "check", "redact", "version", "help", "completion", "__stats":
 		return args
 	}
 	if strings.HasPrefix(first, "-") {
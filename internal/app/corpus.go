@@ -0,0 +1,130 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"syl-wordcount/internal/config"
+)
+
+// corpusTopFiles 是语料级违规最多列出的文件数。
+const corpusTopFiles = 10
+
+// FileTotal 是语料级违规中列出的一个文件及其字符数。
+type FileTotal struct {
+	Path  string `json:"path"`
+	Chars int    `json:"chars"`
+}
+
+// corpusFile 是参与语料级规则的一个输入文件。跳过的文件（二进制、超大、解码失败）也计入文件数，字符数为 0。
+type corpusFile struct {
+	Path    string
+	RelPath string
+	Chars   int
+}
+
+func hasCorpusRules(r config.Rules) bool {
+	return r.MaxTotalChars != nil || r.MaxTotalFiles != nil || r.MaxFilesPerDirectory != nil || len(r.CorpusBudgets) > 0
+}
+
+func validateCorpusRules(r config.Rules) []error {
+	errs := make([]error, 0)
+	nonNegative := func(name string, v *int) {
+		if v != nil && *v < 0 {
+			errs = append(errs, fmt.Errorf("%s 不能为负数：%d", name, *v))
+		}
+	}
+	nonNegative("max_total_chars", r.MaxTotalChars)
+	nonNegative("max_total_files", r.MaxTotalFiles)
+	nonNegative("max_files_per_directory", r.MaxFilesPerDirectory)
+	for i, b := range r.CorpusBudgets {
+		name := fmt.Sprintf("corpus_budgets[%d]", i)
+		if len(b.Files) == 0 {
+			errs = append(errs, fmt.Errorf("%s.files 不能为空", name))
+		} else if err := validateGlobs(b.Files, name+".files"); err != nil {
+			errs = append(errs, err)
+		}
+		if b.MaxTotalChars == nil && b.MaxTotalFiles == nil {
+			errs = append(errs, fmt.Errorf("%s 至少要设置 max_total_chars 或 max_total_files", name))
+		}
+		nonNegative(name+".max_total_chars", b.MaxTotalChars)
+		nonNegative(name+".max_total_files", b.MaxTotalFiles)
+	}
+	return errs
+}
+
+// evaluateCorpusRules 在所有文件处理完后检查整批输入的总量。违规的 scope 为 corpus，
+// top_files 按字符数从多到少列出贡献最大的文件；按目录计数的违规 path 为该目录，其余 path 为空。
+func evaluateCorpusRules(r config.Rules, files []corpusFile) []Violation {
+	violations := make([]Violation, 0)
+	totals := func(idPrefix, path, prefix string, set []corpusFile, maxChars, maxFiles *int) {
+		chars := 0
+		for _, f := range set {
+			chars += f.Chars
+		}
+		if maxChars != nil && chars > *maxChars {
+			violations = append(violations, corpusViolation(idPrefix+"max_total_chars", path, prefix+"总字符数超出上限", chars, *maxChars, set))
+		}
+		if maxFiles != nil && len(set) > *maxFiles {
+			violations = append(violations, corpusViolation(idPrefix+"max_total_files", path, prefix+"文件数超出上限", len(set), *maxFiles, set))
+		}
+	}
+
+	totals("", "", "输入", files, r.MaxTotalChars, r.MaxTotalFiles)
+
+	if r.MaxFilesPerDirectory != nil {
+		byDir := map[string][]corpusFile{}
+		dirs := make([]string, 0)
+		for _, f := range files {
+			d := filepath.Dir(f.Path)
+			if _, ok := byDir[d]; !ok {
+				dirs = append(dirs, d)
+			}
+			byDir[d] = append(byDir[d], f)
+		}
+		sort.Strings(dirs)
+		for _, d := range dirs {
+			if n := len(byDir[d]); n > *r.MaxFilesPerDirectory {
+				violations = append(violations, corpusViolation("max_files_per_directory", d, "目录内文件数超出上限", n, *r.MaxFilesPerDirectory, byDir[d]))
+			}
+		}
+	}
+
+	for i, b := range r.CorpusBudgets {
+		set := make([]corpusFile, 0)
+		for _, f := range files {
+			if matchFileGlobs(b.Files, FileContent{Path: f.Path, RelPath: f.RelPath}) {
+				set = append(set, f)
+			}
+		}
+		totals("corpus_budget.", "", fmt.Sprintf("corpus_budgets[%d]（%s）", i, strings.Join(b.Files, ", ")), set, b.MaxTotalChars, b.MaxTotalFiles)
+	}
+	return violations
+}
+
+func corpusViolation(ruleID, path, msg string, actual, limit int, set []corpusFile) Violation {
+	top := make([]FileTotal, 0, len(set))
+	for _, f := range set {
+		top = append(top, FileTotal{Path: f.Path, Chars: f.Chars})
+	}
+	sort.SliceStable(top, func(a, b int) bool {
+		if top[a].Chars != top[b].Chars {
+			return top[a].Chars > top[b].Chars
+		}
+		return top[a].Path < top[b].Path
+	})
+	if len(top) > corpusTopFiles {
+		top = top[:corpusTopFiles]
+	}
+	return Violation{
+		RuleID:   ruleID,
+		Message:  msg,
+		Path:     path,
+		Actual:   actual,
+		Limit:    limit,
+		Scope:    "corpus",
+		TopFiles: top,
+	}
+}
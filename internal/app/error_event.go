@@ -66,11 +66,18 @@ func hintByCode(code string) errorHint {
 			DocKey:      "input.decode_failed",
 			Recoverable: true,
 		}
-	case "rule_eval_error":
+	case "fix_write_failed":
 		return errorHint{
-			NextAction:  "检查规则配置是否正确（尤其正则表达式）",
-			FixExample:  "syl-wordcount check /path/to/input_dir --config /path/to/rules.yaml",
-			DocKey:      "check.rule_eval_error",
+			NextAction:  "检查文件是否可写，或去掉 --fix 只做检查",
+			FixExample:  "chmod u+w /path/to/file.md && syl-wordcount check /path/to/file.md --config /path/to/rules.yaml --fix",
+			DocKey:      "check.fix_write_failed",
+			Recoverable: true,
+		}
+	case "redact_write_failed":
+		return errorHint{
+			NextAction:  "检查 --out-dir 是否可写，或换一个输出目录",
+			FixExample:  "syl-wordcount redact /path/to/docs --config /path/to/rules.yaml --out-dir /tmp/redacted",
+			DocKey:      "redact.write_failed",
 			Recoverable: true,
 		}
 	default:
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"os"
+
+	"syl-wordcount/internal/textutil"
+)
+
+// applyFixes 把带修复建议的违规写回文件，返回未修复的违规与已修复数量。
+// 写回时保留原有编码与换行符。
+func applyFixes(fc FileContent, violations []Violation) ([]Violation, int, error) {
+	edits := make([]textutil.TextEdit, 0)
+	owners := make([]int, 0)
+	for i, v := range violations {
+		if v.Fix == nil {
+			continue
+		}
+		edits = append(edits, *v.Fix)
+		owners = append(owners, i)
+	}
+	if len(edits) == 0 {
+		return violations, 0, nil
+	}
+
+	text, applied := textutil.ApplyEdits(fc.Text, edits)
+	fixed := map[int]struct{}{}
+	for i, ok := range applied {
+		if ok {
+			fixed[owners[i]] = struct{}{}
+		}
+	}
+	if len(fixed) == 0 {
+		return violations, 0, nil
+	}
+
+	data, err := textutil.Encode(text, fc.Encoding)
+	if err != nil {
+		return violations, 0, err
+	}
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(fc.Path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(fc.Path, data, mode); err != nil {
+		return violations, 0, err
+	}
+
+	remaining := make([]Violation, 0, len(violations)-len(fixed))
+	for i, v := range violations {
+		if _, ok := fixed[i]; ok {
+			continue
+		}
+		remaining = append(remaining, v)
+	}
+	return remaining, len(fixed), nil
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdATXHeadingRegex    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?[ \t]*$`)
+	mdSetextUnderline    = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdFenceOpenRegex     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})(.*)$")
+	mdThematicBreakRegex = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdListItemRegex      = regexp.MustCompile(`^ {0,3}(?:[-+*]|\d{1,9}[.)])(?:[ \t]|$)`)
+	mdHTMLHeadingRegex   = regexp.MustCompile(`(?i)^ {0,3}<h([1-6])(?:\s[^>]*)?>(.*?)</h([1-6])>\s*$`)
+	htmlTagRegex         = regexp.MustCompile(`<[^>]*>`)
+)
+
+// markdownSplitter 是 Markdown 的章节切分器，HTMLHeadings 为 true 时额外识别单行 <h1>~<h6>。
+type markdownSplitter struct {
+	HTMLHeadings bool
+}
+
+func (m markdownSplitter) Headings(lines []string) []headingPos {
+	return scanMarkdownHeadings(lines, m.HTMLHeadings)
+}
+
+// scanMarkdownHeadings 按 CommonMark 的块结构找出标题：ATX（# 标题）与 setext（=== / --- 下划线），
+// 跳过 YAML front matter、围栏代码块和缩进代码块；htmlHeadings 为 true 时还识别单行 <h1>~<h6>。
+func scanMarkdownHeadings(lines []string, htmlHeadings bool) []headingPos {
+	headings := make([]headingPos, 0)
+	start := frontMatterEnd(lines)
+
+	fenceChar, fenceLen := byte(0), 0
+	paraStart := -1  // 当前可作为 setext 标题内容的段落首行，-1 表示没有
+	inBlock := false // 上一行属于段落/列表/引用，缩进行视为延续而不是代码
+	for i := start; i < len(lines); i++ {
+		ln := lines[i]
+		if fenceLen > 0 {
+			if isClosingFence(ln, fenceChar, fenceLen) {
+				fenceChar, fenceLen = 0, 0
+			}
+			continue
+		}
+		if strings.TrimSpace(ln) == "" {
+			paraStart, inBlock = -1, false
+			continue
+		}
+		if !inBlock && leadingIndent(ln) >= 4 {
+			continue
+		}
+		if m := mdFenceOpenRegex.FindStringSubmatch(ln); m != nil && !(m[1][0] == '`' && strings.Contains(m[2], "`")) {
+			fenceChar, fenceLen = m[1][0], len(m[1])
+			paraStart, inBlock = -1, false
+			continue
+		}
+		if paraStart >= 0 {
+			if m := mdSetextUnderline.FindStringSubmatch(ln); m != nil {
+				title := setextTitle(lines[paraStart:i])
+				if title != "" {
+					level := 1
+					if m[1][0] == '-' {
+						level = 2
+					}
+					headings = append(headings, headingPos{Title: title, Start: paraStart, Line: paraStart, Level: level, BodyStart: i + 1, Syntax: "setext"})
+				}
+				paraStart, inBlock = -1, false
+				continue
+			}
+		}
+		if m := mdATXHeadingRegex.FindStringSubmatch(ln); m != nil {
+			if title := normalizeHeadingTitle(m[2]); title != "" {
+				headings = append(headings, headingPos{Title: title, Start: i, Line: i, Level: len(m[1]), BodyStart: i + 1, Syntax: "atx"})
+			}
+			paraStart, inBlock = -1, false
+			continue
+		}
+		if htmlHeadings {
+			if m := mdHTMLHeadingRegex.FindStringSubmatch(ln); m != nil && m[1] == m[3] {
+				if title := htmlHeadingTitle(m[2]); title != "" {
+					headings = append(headings, headingPos{Title: title, Start: i, Line: i, Level: int(m[1][0] - '0'), BodyStart: i + 1, Syntax: "html"})
+				}
+				paraStart, inBlock = -1, false
+				continue
+			}
+		}
+		if mdThematicBreakRegex.MatchString(ln) {
+			paraStart, inBlock = -1, false
+			continue
+		}
+		trimmed := strings.TrimLeft(ln, " \t")
+		if strings.HasPrefix(trimmed, ">") || mdListItemRegex.MatchString(ln) || strings.HasPrefix(trimmed, "<") || strings.HasPrefix(trimmed, "|") {
+			// 引用、列表、HTML 块与表格里的 ---/=== 不构成 setext 标题
+			paraStart, inBlock = -1, true
+			continue
+		}
+		if paraStart < 0 && !inBlock {
+			paraStart = i
+		}
+		inBlock = true
+	}
+	return headings
+}
+
+func normalizeHeadingTitle(s string) string {
+	title := strings.TrimSpace(s)
+	rest := strings.TrimRight(title, "#")
+	// 结尾的 # 序列只有前面是空白或占满整个标题时才是闭合序列，"C#" 这样的 # 属于标题本身
+	if rest == "" || strings.HasSuffix(rest, " ") || strings.HasSuffix(rest, "\t") {
+		title = strings.TrimSpace(rest)
+	}
+	return title
+}
+
+// frontMatterEnd 返回 YAML front matter 之后的第一行下标；没有 front matter 时为 0。
+func frontMatterEnd(lines []string) int {
+	if len(lines) == 0 || strings.TrimRight(lines[0], " \t") != "---" {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		s := strings.TrimRight(lines[i], " \t")
+		if s == "---" || s == "..." {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func isClosingFence(ln string, ch byte, n int) bool {
+	if leadingIndent(ln) > 3 {
+		return false
+	}
+	s := strings.TrimSpace(ln)
+	if len(s) < n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != ch {
+			return false
+		}
+	}
+	return true
+}
+
+// leadingIndent 计算行首缩进列数，制表符按 4 列对齐。
+func leadingIndent(ln string) int {
+	col := 0
+	for i := 0; i < len(ln); i++ {
+		switch ln[i] {
+		case ' ':
+			col++
+		case '\t':
+			col += 4 - col%4
+		default:
+			return col
+		}
+	}
+	return col
+}
+
+func setextTitle(lines []string) string {
+	parts := make([]string, 0, len(lines))
+	for _, ln := range lines {
+		if s := strings.TrimSpace(ln); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func htmlHeadingTitle(inner string) string {
+	s := htmlTagRegex.ReplaceAllString(inner, "")
+	return strings.TrimSpace(html.UnescapeString(s))
+}
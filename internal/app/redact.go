@@ -0,0 +1,213 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"syl-wordcount/internal/textutil"
+)
+
+// DefaultRedactMask 是 redact 的默认遮盖模板，{rule_id} 会替换为命中的规则 ID。
+const DefaultRedactMask = "[REDACTED:{rule_id}]"
+
+// redactHit 是一处待遮盖的命中，Start/End 为原文（保留原有换行符）中的字节偏移。
+type redactHit struct {
+	RuleID string
+	Start  int
+	End    int
+}
+
+// hasRedactRules 判断是否配置了 redact 能用的规则：全局 forbidden_patterns 或 secrets / pii。
+func hasRedactRules(rs *RuleSet) bool {
+	return rs.sensitive != nil || (rs.global != nil && len(rs.global.Forbidden) > 0)
+}
+
+// redactHits 找出全局 forbidden_patterns 与 secrets / pii 的全部命中，按位置排序并去掉重叠
+// （起点相同时保留较长的一条）。匹配在换行符统一为 \n 的文本上进行，与 check 的结果一致，
+// 再换算回原文偏移；命中末尾的换行符不遮盖，保留原有换行。
+func (rs *RuleSet) redactHits(text string) []redactHit {
+	norm, rawOff := normalizeWithOffsets(text)
+	hits := make([]redactHit, 0)
+	add := func(id string, start, end int) {
+		for end > start && norm[end-1] == '\n' {
+			end--
+		}
+		if end > start {
+			hits = append(hits, redactHit{RuleID: id, Start: start, End: end})
+		}
+	}
+	if rs.global != nil {
+		for _, pr := range rs.global.Forbidden {
+			for _, idx := range pr.hits(norm) {
+				add(pr.ruleID("forbidden_pattern"), idx[0], idx[1])
+			}
+		}
+	}
+	if rs.sensitive != nil {
+		for _, m := range textutil.FindSensitive(norm, *rs.sensitive) {
+			add(m.RuleID, m.Start, m.End)
+		}
+	}
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].Start != hits[b].Start {
+			return hits[a].Start < hits[b].Start
+		}
+		return hits[a].End > hits[b].End
+	})
+	kept := make([]redactHit, 0, len(hits))
+	lastEnd := -1
+	for _, h := range hits {
+		if h.Start < lastEnd {
+			continue
+		}
+		lastEnd = h.End
+		kept = append(kept, h)
+	}
+	for i := range kept {
+		kept[i].Start = rawOff[kept[i].Start]
+		kept[i].End = rawOff[kept[i].End]
+	}
+	return kept
+}
+
+// normalizeWithOffsets 与 normalize 相同，另外返回规范化文本每个字节位置（含末尾）对应的原文偏移。
+func normalizeWithOffsets(text string) (string, []int) {
+	var b strings.Builder
+	b.Grow(len(text))
+	offs := make([]int, 0, len(text)+1)
+	for i := 0; i < len(text); i++ {
+		offs = append(offs, i)
+		if text[i] == '\r' {
+			b.WriteByte('\n')
+			if i+1 < len(text) && text[i+1] == '\n' {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(text[i])
+	}
+	offs = append(offs, len(text))
+	return b.String(), offs
+}
+
+// rawLineStarts 返回原文每行起始的字节偏移，\r\n、\r、\n 都算一次换行。
+func rawLineStarts(text string) []int {
+	starts := []int{0}
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\r':
+			if i+1 < len(text) && text[i+1] == '\n' {
+				i++
+			}
+			starts = append(starts, i+1)
+		case '\n':
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// rawPosition 把原文字节偏移换算成行号与 rune 列号（都从 1 开始）。
+func rawPosition(text string, starts []int, off int) (int, int) {
+	i := sort.Search(len(starts), func(i int) bool { return starts[i] > off }) - 1
+	return i + 1, textutil.RuneColumnAtByteOffset(text[starts[i]:], off-starts[i])
+}
+
+// redactText 用遮盖模板替换所有命中，返回脱敏后的文本与每处命中的 redaction 事件。
+func redactText(path, text string, hits []redactHit, mask string) (string, []map[string]any) {
+	starts := rawLineStarts(text)
+	events := make([]map[string]any, 0, len(hits))
+	var b strings.Builder
+	prev := 0
+	for _, h := range hits {
+		replacement := strings.ReplaceAll(mask, "{rule_id}", h.RuleID)
+		b.WriteString(text[prev:h.Start])
+		b.WriteString(replacement)
+		prev = h.End
+		line, col := rawPosition(text, starts, h.Start)
+		endLine, endCol := rawPosition(text, starts, h.End)
+		events = append(events, map[string]any{
+			"type":        "redaction",
+			"path":        path,
+			"rule_id":     h.RuleID,
+			"line":        line,
+			"column":      col,
+			"end_line":    endLine,
+			"end_column":  endCol,
+			"replacement": replacement,
+		})
+	}
+	b.WriteString(text[prev:])
+	return b.String(), events
+}
+
+// redactOutputs 为每个输入文件确定输出路径：文件相对所在输入目录的路径接到 outDir 下，
+// 输入是单个文件时直接用文件名。两个文件写到同一路径、或输出会覆盖输入文件时报错。
+func redactOutputs(roots, paths []string, outDir string) (map[string]string, error) {
+	out := make(map[string]string, len(paths))
+	owner := map[string]string{}
+	for _, p := range paths {
+		rel, err := filepath.Rel(inputRoot(roots, p), p)
+		if err != nil {
+			rel = filepath.Base(p)
+		}
+		dst := filepath.Join(outDir, rel)
+		if dst == p {
+			return nil, fmt.Errorf("输出路径与输入文件相同：%s，请换一个 --out-dir", p)
+		}
+		if prev, ok := owner[dst]; ok {
+			return nil, fmt.Errorf("%s 与 %s 会写到同一个输出路径：%s", prev, p, dst)
+		}
+		owner[dst] = p
+		out[p] = dst
+	}
+	return out, nil
+}
+
+// redactFile 生成单个文件的脱敏副本：按原编码写到 --out-dir，没有 --out-dir 时留给调用方写到标准输出。
+func redactFile(fr fileResult, decoded textutil.Decoded, metrics textutil.Metrics, opts Options, cfg RuntimeConfig) fileResult {
+	path := fr.Path
+	hits := cfg.RuleSet.redactHits(decoded.Text)
+	text, events := redactText(path, decoded.Text, hits, opts.Mask)
+	encoded, err := textutil.Encode(text, decoded.Encoding)
+	if err != nil {
+		fr.HasInputErr = true
+		fr.Events = append(fr.Events, buildErrorEvent("input", "redact_write_failed", path, err.Error()))
+		return fr
+	}
+	output := "-"
+	if opts.OutDir != "" {
+		output = cfg.OutPaths[path]
+		mode := os.FileMode(0o644)
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+			fr.HasInputErr = true
+			fr.Events = append(fr.Events, buildErrorEvent("input", "redact_write_failed", path, err.Error()))
+			return fr
+		}
+		if err := os.WriteFile(output, encoded, mode); err != nil {
+			fr.HasInputErr = true
+			fr.Events = append(fr.Events, buildErrorEvent("input", "redact_write_failed", path, err.Error()))
+			return fr
+		}
+	} else {
+		fr.Redacted = encoded
+	}
+	fr.Events = append(fr.Events, events...)
+	fr.Events = append(fr.Events, map[string]any{
+		"type":        "redacted_file",
+		"path":        path,
+		"status":      "ok",
+		"output":      output,
+		"encoding":    decoded.Encoding,
+		"line_ending": metrics.LineEnding,
+		"redactions":  len(hits),
+	})
+	fr.Processed = true
+	return fr
+}
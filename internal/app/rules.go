@@ -2,8 +2,6 @@ package app
 
 import (
 	"fmt"
-	"path/filepath"
-	"regexp"
 	"strings"
 	"unicode/utf8"
 
@@ -13,10 +11,9 @@ import (
 
 const contextChars = 40
 
-var mdHeadingRegex = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.+?)\s*$`)
-
 type FileContent struct {
 	Path     string
+	RelPath  string
 	Data     []byte
 	Text     string
 	Encoding string
@@ -31,10 +28,23 @@ type Violation struct {
 	Column              int
 	OverflowStartColumn int
 	LineEndColumn       int
+	EndLine             int // 命中跨行时的结束位置（不含），否则为 0
+	EndColumn           int
 	Snippet             string
 	Actual              any
 	Limit               any
 	Scope               string
+	Suggestion          string
+	Fix                 *textutil.TextEdit
+	Locations           []Location  // 跨文件违规涉及的所有位置
+	TopFiles            []FileTotal // 语料级违规中贡献最大的文件
+}
+
+type Warning struct {
+	Code   string
+	Path   string
+	Line   int // 0 表示不指向具体行
+	Detail string
 }
 
 type evalScope struct {
@@ -44,27 +54,29 @@ type evalScope struct {
 	Text      string
 	Metrics   textutil.Metrics
 	StartLine int
+	Markdown  bool
 }
 
-type markdownSection struct {
+type docSection struct {
 	Heading     string
 	HeadingLine int
 	Level       int
+	Syntax      string
 	StartLine   int
 	EndLine     int
 	Text        string
 	Metrics     textutil.Metrics
 }
 
+// headingPos 是切分器识别出的一个标题（行号从 0 开始）：Start 是标题结构的首行（如 rst 的上划线），
+// Line 是标题文本所在行，BodyStart 是正文首行。
 type headingPos struct {
-	Title string
-	Line  int
-	Level int
-}
-
-type compiledPattern struct {
-	Source string
-	Regex  *regexp.Regexp
+	Title     string
+	Start     int
+	Line      int
+	Level     int
+	BodyStart int
+	Syntax    string
 }
 
 type scopeRules struct {
@@ -74,96 +86,67 @@ type scopeRules struct {
 	MaxLines                 *int
 	MaxLineWidth             *int
 	AvgLineWidth             *int
+	MaxSentenceChars         *int
+	MaxParagraphChars        *int
+	MaxParagraphLines        *int
+	MinReadability           *float64
+	MaxGradeLevel            *float64
+	MaxReadingTime           string
+	MaxSpeakingTime          string
+	ReadingSpeed             textutil.ReadingSpeed
 	NoTrailingSpaces         bool
 	NoTabs                   bool
 	NoFullwidthSpace         bool
+	NoZeroWidthChars         bool
+	NoBidiControls           bool
+	NoNBSP                   bool
+	RequireNFC               bool
+	ForbiddenCodepoints      []string
+	ZhTypography             config.ZhTypographyRules
+	ChineseVariant           string
+	AllowedLanguages         []string
+	RequireImageAlt          bool
+	ForbidBareURLs           bool
+	ForbidEmptyLinkText      bool
+	ForbidGenericLinkText    bool
+	GenericLinkTexts         []string
+	Terminology              config.Terminology
 	MaxConsecutiveBlankLines *int
+	NoDuplicateLines         config.DuplicateRule
+	NoDuplicateParagraphs    config.DuplicateRule
 	ForbiddenPatterns        []config.PatternRule
 	RequiredPatterns         []config.PatternRule
+	ForbiddenWords           config.WordListRule
+	RequiredWords            config.WordListRule
 }
 
 type compiledScopeRules struct {
-	Rules     scopeRules
-	Forbidden []compiledPattern
-	Required  []compiledPattern
+	Rules           scopeRules
+	MaxReadingSecs  int
+	MaxSpeakingSecs int
+	Forbidden       []compiledPattern
+	Required        []compiledPattern
+	Codepoints      []codepointRange
+	ZhVariant       string
+	Languages       []string
+	Terms           []compiledTerm
+	ForbiddenWords  *textutil.WordMatcher
+	RequiredWords   *textutil.WordMatcher
 }
 
+// EvaluateRules 编译规则并检查单个文件。检查多个文件时应先 CompileRules 再复用 RuleSet。
 func EvaluateRules(fc FileContent, rules config.Rules) ([]Violation, []error) {
-	violations := make([]Violation, 0)
-	errs := make([]error, 0)
-
-	if len(rules.AllowedExtensions) > 0 {
-		ext := strings.ToLower(filepath.Ext(fc.Path))
-		ok := false
-		for _, a := range rules.AllowedExtensions {
-			if strings.ToLower(strings.TrimSpace(a)) == ext {
-				ok = true
-				break
-			}
-		}
-		if !ok {
-			violations = append(violations, fileLevel(fc.Path, "allowed_extensions", "文件扩展名不在允许范围", ext, rules.AllowedExtensions))
-		}
-	}
-
-	if s := strings.TrimSpace(rules.MaxFileSize); s != "" {
-		maxBytes, err := config.ParseSizeToBytes(s)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("max_file_size 配置错误：%w", err))
-		} else if maxBytes > 0 && int64(len(fc.Data)) > maxBytes {
-			violations = append(violations, fileLevel(fc.Path, "max_file_size", "文件大小超出上限", len(fc.Data), maxBytes))
-		}
-	}
-
-	globalSR := scopeRulesFromGlobal(rules)
-	if hasAnyScopeRule(globalSR) {
-		compiled, cErrs := compileScopeRules(globalSR, "")
-		errs = append(errs, cErrs...)
-		fileScope := evalScope{
-			Scope:     "file",
-			Text:      fc.Text,
-			Metrics:   fc.Metrics,
-			StartLine: 1,
-		}
-		violations = append(violations, evaluateScope(fc.Path, fileScope, compiled)...)
-	}
-
-	sections := collectMarkdownSections(fc.Metrics.LinesText)
-	for i, sr := range rules.SectionRules {
-		heading := strings.TrimSpace(sr.HeadingContains)
-		if heading == "" {
-			errs = append(errs, fmt.Errorf("section_rules[%d] 缺少 heading_contains", i))
-			continue
-		}
-
-		srScope := scopeRulesFromSection(sr.Rules)
-		if !hasAnyScopeRule(srScope) {
-			errs = append(errs, fmt.Errorf("section_rules[%d].rules 至少要设置一条规则", i))
-			continue
-		}
-
-		compiled, cErrs := compileScopeRules(srScope, fmt.Sprintf("section_rules[%d].rules.", i))
-		errs = append(errs, cErrs...)
-
-		for _, sec := range sections {
-			if !strings.Contains(sec.Heading, heading) {
-				continue
-			}
-			scope := evalScope{
-				Scope:     "section",
-				Label:     sec.Heading,
-				LabelLine: sec.HeadingLine,
-				Text:      sec.Text,
-				Metrics:   sec.Metrics,
-				StartLine: sec.StartLine,
-			}
-			violations = append(violations, evaluateScope(fc.Path, scope, compiled)...)
-		}
-	}
-
+	violations, _, errs := EvaluateRulesWithWarnings(fc, rules)
 	return violations, errs
 }
 
+// EvaluateRulesWithWarnings 与 EvaluateRules 相同，另外返回不影响退出码的提示（如章节规则没有匹配到标题）。
+func EvaluateRulesWithWarnings(fc FileContent, rules config.Rules) ([]Violation, []Warning, []error) {
+	rs, errs := CompileRules(rules)
+	ev := rs.Evaluate(fc)
+	return ev.Violations, ev.Warnings, errs
+}
+
 func scopeRulesFromGlobal(r config.Rules) scopeRules {
 	return scopeRules{
 		MinChars:                 r.MinChars,
@@ -172,12 +155,37 @@ func scopeRulesFromGlobal(r config.Rules) scopeRules {
 		MaxLines:                 r.MaxLines,
 		MaxLineWidth:             r.MaxLineWidth,
 		AvgLineWidth:             r.AvgLineWidth,
+		MaxSentenceChars:         r.MaxSentenceChars,
+		MaxParagraphChars:        r.MaxParagraphChars,
+		MaxParagraphLines:        r.MaxParagraphLines,
+		MinReadability:           r.MinReadability,
+		MaxGradeLevel:            r.MaxGradeLevel,
+		MaxReadingTime:           r.MaxReadingTime,
+		MaxSpeakingTime:          r.MaxSpeakingTime,
 		NoTrailingSpaces:         r.NoTrailingSpaces,
 		NoTabs:                   r.NoTabs,
 		NoFullwidthSpace:         r.NoFullwidthSpace,
+		NoZeroWidthChars:         r.NoZeroWidthChars,
+		NoBidiControls:           r.NoBidiControls,
+		NoNBSP:                   r.NoNBSP,
+		RequireNFC:               r.RequireNFC,
+		ForbiddenCodepoints:      r.ForbiddenCodepoints,
+		ZhTypography:             r.ZhTypography,
+		ChineseVariant:           r.ChineseVariant,
+		AllowedLanguages:         r.AllowedLanguages,
+		RequireImageAlt:          r.RequireImageAlt,
+		ForbidBareURLs:           r.ForbidBareURLs,
+		ForbidEmptyLinkText:      r.ForbidEmptyLinkText,
+		ForbidGenericLinkText:    r.ForbidGenericLinkText,
+		GenericLinkTexts:         r.GenericLinkTexts,
+		Terminology:              r.Terminology,
 		MaxConsecutiveBlankLines: r.MaxConsecutiveBlankLines,
+		NoDuplicateLines:         r.NoDuplicateLines,
+		NoDuplicateParagraphs:    r.NoDuplicateParagraphs,
 		ForbiddenPatterns:        r.ForbiddenPatterns,
 		RequiredPatterns:         r.RequiredPatterns,
+		ForbiddenWords:           r.ForbiddenWordsFile,
+		RequiredWords:            r.RequiredWordsFile,
 	}
 }
 
@@ -189,9 +197,30 @@ func scopeRulesFromSection(r config.SectionScopedRules) scopeRules {
 		MaxLines:                 r.MaxLines,
 		MaxLineWidth:             r.MaxLineWidth,
 		AvgLineWidth:             r.AvgLineWidth,
+		MaxSentenceChars:         r.MaxSentenceChars,
+		MaxParagraphChars:        r.MaxParagraphChars,
+		MaxParagraphLines:        r.MaxParagraphLines,
+		MinReadability:           r.MinReadability,
+		MaxGradeLevel:            r.MaxGradeLevel,
+		MaxReadingTime:           r.MaxReadingTime,
+		MaxSpeakingTime:          r.MaxSpeakingTime,
 		NoTrailingSpaces:         r.NoTrailingSpaces,
 		NoTabs:                   r.NoTabs,
 		NoFullwidthSpace:         r.NoFullwidthSpace,
+		NoZeroWidthChars:         r.NoZeroWidthChars,
+		NoBidiControls:           r.NoBidiControls,
+		NoNBSP:                   r.NoNBSP,
+		RequireNFC:               r.RequireNFC,
+		ForbiddenCodepoints:      r.ForbiddenCodepoints,
+		ZhTypography:             r.ZhTypography,
+		ChineseVariant:           r.ChineseVariant,
+		AllowedLanguages:         r.AllowedLanguages,
+		RequireImageAlt:          r.RequireImageAlt,
+		ForbidBareURLs:           r.ForbidBareURLs,
+		ForbidEmptyLinkText:      r.ForbidEmptyLinkText,
+		ForbidGenericLinkText:    r.ForbidGenericLinkText,
+		GenericLinkTexts:         r.GenericLinkTexts,
+		Terminology:              r.Terminology,
 		MaxConsecutiveBlankLines: r.MaxConsecutiveBlankLines,
 		ForbiddenPatterns:        r.ForbiddenPatterns,
 		RequiredPatterns:         r.RequiredPatterns,
@@ -202,10 +231,31 @@ func hasAnyScopeRule(r scopeRules) bool {
 	if r.MinChars != nil || r.MaxChars != nil || r.MinLines != nil || r.MaxLines != nil || r.MaxLineWidth != nil || r.AvgLineWidth != nil || r.MaxConsecutiveBlankLines != nil {
 		return true
 	}
+	if r.MaxSentenceChars != nil || r.MaxParagraphChars != nil || r.MaxParagraphLines != nil || r.MinReadability != nil || r.MaxGradeLevel != nil {
+		return true
+	}
+	if strings.TrimSpace(r.MaxReadingTime) != "" || strings.TrimSpace(r.MaxSpeakingTime) != "" {
+		return true
+	}
 	if r.NoTrailingSpaces || r.NoTabs || r.NoFullwidthSpace {
 		return true
 	}
-	if len(r.ForbiddenPatterns) > 0 || len(r.RequiredPatterns) > 0 {
+	if r.NoZeroWidthChars || r.NoBidiControls || r.NoNBSP || r.RequireNFC || len(r.ForbiddenCodepoints) > 0 {
+		return true
+	}
+	if r.ZhTypography.Any() || strings.TrimSpace(r.ChineseVariant) != "" || len(r.AllowedLanguages) > 0 {
+		return true
+	}
+	if r.RequireImageAlt || r.ForbidBareURLs || r.ForbidEmptyLinkText || r.ForbidGenericLinkText {
+		return true
+	}
+	if len(r.Terminology.Terms) > 0 || len(r.ForbiddenPatterns) > 0 || len(r.RequiredPatterns) > 0 {
+		return true
+	}
+	if r.ForbiddenWords.Any() || r.RequiredWords.Any() {
+		return true
+	}
+	if r.NoDuplicateLines.Enabled || r.NoDuplicateParagraphs.Enabled {
 		return true
 	}
 	return false
@@ -214,18 +264,70 @@ func hasAnyScopeRule(r scopeRules) bool {
 func compileScopeRules(r scopeRules, prefix string) (compiledScopeRules, []error) {
 	forbidden, ferrs := compilePatternRules(r.ForbiddenPatterns, prefix+"forbidden_patterns")
 	required, rerrs := compilePatternRules(r.RequiredPatterns, prefix+"required_patterns")
-	errs := make([]error, 0, len(ferrs)+len(rerrs))
+	codepoints, cerrs := parseCodepointRanges(r.ForbiddenCodepoints, prefix+"forbidden_codepoints")
+	errs := make([]error, 0, len(ferrs)+len(rerrs)+len(cerrs))
 	errs = append(errs, ferrs...)
 	errs = append(errs, rerrs...)
-	return compiledScopeRules{Rules: r, Forbidden: forbidden, Required: required}, errs
+	errs = append(errs, cerrs...)
+	terms, terrs := compileTerminology(r.Terminology, prefix+"terminology")
+	errs = append(errs, terrs...)
+	zhVariant, err := parseChineseVariant(r.ChineseVariant)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%schinese_variant 配置错误：%w", prefix, err))
+	}
+	languages, err := parseAllowedLanguages(r.AllowedLanguages)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%sallowed_languages 配置错误：%w", prefix, err))
+	}
+	for _, d := range []struct {
+		name string
+		rule config.DuplicateRule
+	}{{"no_duplicate_lines", r.NoDuplicateLines}, {"no_duplicate_paragraphs", r.NoDuplicateParagraphs}} {
+		if err := validateDuplicateRule(d.rule); err != nil {
+			errs = append(errs, fmt.Errorf("%s%s 配置错误：%w", prefix, d.name, err))
+		}
+	}
+	maxReading, err := config.ParseDurationSeconds(r.MaxReadingTime)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%smax_reading_time 配置错误：%w", prefix, err))
+	}
+	maxSpeaking, err := config.ParseDurationSeconds(r.MaxSpeakingTime)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%smax_speaking_time 配置错误：%w", prefix, err))
+	}
+	return compiledScopeRules{
+		Rules:           r,
+		MaxReadingSecs:  maxReading,
+		MaxSpeakingSecs: maxSpeaking,
+		Forbidden:       forbidden,
+		Required:        required,
+		Codepoints:      codepoints,
+		ZhVariant:       zhVariant,
+		Languages:       languages,
+		Terms:           terms,
+		ForbiddenWords:  newWordMatcher(r.ForbiddenWords),
+		RequiredWords:   newWordMatcher(r.RequiredWords),
+	}, errs
 }
 
 func evaluateScope(path string, scope evalScope, cr compiledScopeRules) []Violation {
 	violations := make([]Violation, 0)
 	violations = append(violations, evaluateScalarRules(path, scope, cr.Rules)...)
 	violations = append(violations, evaluateLineRules(path, scope, cr.Rules)...)
+	violations = append(violations, evaluateSegmentRules(path, scope, cr.Rules)...)
+	violations = append(violations, evaluateDuplicateLines(path, scope, cr.Rules.NoDuplicateLines)...)
+	violations = append(violations, evaluateDuplicateParagraphs(path, scope, cr.Rules.NoDuplicateParagraphs)...)
+	violations = append(violations, evaluateReadingTime(path, scope, cr)...)
+	violations = append(violations, evaluateUnicodeRules(path, scope, cr)...)
+	violations = append(violations, evaluateZhTypography(path, scope, cr.Rules.ZhTypography)...)
+	violations = append(violations, evaluateChineseVariant(path, scope, cr.ZhVariant)...)
+	violations = append(violations, evaluateAllowedLanguages(path, scope, cr.Languages)...)
+	violations = append(violations, evaluateLinkTextRules(path, scope, cr.Rules)...)
+	violations = append(violations, evaluateTerminology(path, scope, cr.Terms)...)
 	violations = append(violations, evaluateForbiddenPatterns(path, scope, cr.Forbidden)...)
+	violations = append(violations, evaluateForbiddenWords(path, scope, cr.ForbiddenWords)...)
 	violations = append(violations, evaluateRequiredPatterns(path, scope, cr.Required)...)
+	violations = append(violations, evaluateRequiredWords(path, scope, cr.RequiredWords)...)
 	return violations
 }
 
@@ -375,119 +477,6 @@ func evaluateLineRules(path string, scope evalScope, rules scopeRules) []Violati
 	return violations
 }
 
-func evaluateForbiddenPatterns(path string, scope evalScope, rules []compiledPattern) []Violation {
-	if len(rules) == 0 {
-		return nil
-	}
-	normText := normalize(scope.Text)
-	lineOffsets := textutil.BuildLineOffsets(scope.Metrics.LinesText)
-	violations := make([]Violation, 0)
-	for _, pr := range rules {
-		idxs := pr.Regex.FindAllStringIndex(normText, -1)
-		for _, idx := range idxs {
-			pos := textutil.LineAndColumnByOffset(scope.Metrics.LinesText, lineOffsets, idx[0])
-			line := 0
-			if pos.Line > 0 {
-				line = scope.StartLine + pos.Line - 1
-			}
-			violations = append(violations, Violation{
-				RuleID:  "forbidden_pattern",
-				Message: scopeMessage(scope, "命中禁止模式"),
-				Path:    path,
-				Line:    line,
-				Column:  pos.Column,
-				Snippet: textutil.SnippetByRune(normText, idx[0], contextChars),
-				Actual:  normText[idx[0]:idx[1]],
-				Limit:   pr.Source,
-				Scope:   scope.Scope,
-			})
-		}
-	}
-	return violations
-}
-
-func evaluateRequiredPatterns(path string, scope evalScope, rules []compiledPattern) []Violation {
-	if len(rules) == 0 {
-		return nil
-	}
-	normText := normalize(scope.Text)
-	violations := make([]Violation, 0)
-	for _, pr := range rules {
-		if pr.Regex.MatchString(normText) {
-			continue
-		}
-		violations = append(violations, scopeLevelViolation(path, scope, "required_pattern", scopeMessage(scope, "缺少必需模式"), "not_found", pr.Source))
-	}
-	return violations
-}
-
-func compilePatternRules(list []config.PatternRule, kind string) ([]compiledPattern, []error) {
-	out := make([]compiledPattern, 0, len(list))
-	errs := make([]error, 0)
-	for _, pr := range list {
-		if strings.TrimSpace(pr.Pattern) == "" {
-			continue
-		}
-		rx, err := compileRule(pr)
-		if err != nil {
-			errs = append(errs, fmt.Errorf("%s 编译失败：%w", kind, err))
-			continue
-		}
-		out = append(out, compiledPattern{Source: pr.Pattern, Regex: rx})
-	}
-	return out, errs
-}
-
-func collectMarkdownSections(lines []string) []markdownSection {
-	headings := make([]headingPos, 0)
-	for i, ln := range lines {
-		m := mdHeadingRegex.FindStringSubmatch(ln)
-		if len(m) != 3 {
-			continue
-		}
-		title := normalizeHeadingTitle(m[2])
-		if title == "" {
-			continue
-		}
-		headings = append(headings, headingPos{Title: title, Line: i, Level: len(m[1])})
-	}
-	if len(headings) == 0 {
-		return nil
-	}
-
-	sections := make([]markdownSection, 0, len(headings))
-	for i, h := range headings {
-		end := len(lines) - 1
-		for j := i + 1; j < len(headings); j++ {
-			if headings[j].Level <= h.Level {
-				end = headings[j].Line - 1
-				break
-			}
-		}
-		contentStart := h.Line + 1
-		content := ""
-		if contentStart <= end {
-			content = strings.Join(lines[contentStart:end+1], "\n")
-		}
-		sections = append(sections, markdownSection{
-			Heading:     h.Title,
-			HeadingLine: h.Line + 1,
-			Level:       h.Level,
-			StartLine:   contentStart + 1,
-			EndLine:     end + 1,
-			Text:        content,
-			Metrics:     textutil.ComputeMetrics(content),
-		})
-	}
-	return sections
-}
-
-func normalizeHeadingTitle(s string) string {
-	title := strings.TrimSpace(s)
-	title = strings.TrimSpace(strings.TrimRight(title, "#"))
-	return title
-}
-
 func scopeMessage(scope evalScope, msg string) string {
 	if scope.Scope == "section" {
 		return fmt.Sprintf("章节[%s]%s", scope.Label, msg)
@@ -512,14 +501,6 @@ func scopeLevelViolation(path string, scope evalScope, ruleID, msg string, actua
 	return fileLevel(path, ruleID, msg, actual, limit)
 }
 
-func compileRule(pr config.PatternRule) (*regexp.Regexp, error) {
-	caseSensitive := true
-	if pr.CaseSensitive != nil {
-		caseSensitive = *pr.CaseSensitive
-	}
-	return textutil.CompilePattern(pr.Pattern, caseSensitive)
-}
-
 func fileLevel(path, ruleID, msg string, actual, limit any) Violation {
 	return Violation{
 		RuleID:  ruleID,
@@ -542,6 +523,20 @@ func snippetLine(ln string) string {
 	return string(r[:80]) + "..."
 }
 
+// snippetLines 返回第 from~to 行（从 1 开始）的片段，每行按 snippetLine 截断，最多 10 行。
+func snippetLines(lines []string, from, to int) string {
+	const maxLines = 10
+	parts := make([]string, 0, maxLines+1)
+	for i := from; i <= to && i <= len(lines); i++ {
+		if len(parts) == maxLines {
+			parts = append(parts, "...")
+			break
+		}
+		parts = append(parts, snippetLine(lines[i-1]))
+	}
+	return strings.Join(parts, "\n")
+}
+
 func normalize(s string) string {
 	s = strings.ReplaceAll(s, "\r\n", "\n")
 	s = strings.ReplaceAll(s, "\r", "\n")
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+const (
+	defaultDuplicateLineChars      = 20
+	defaultDuplicateParagraphChars = 40
+)
+
+// Location 是跨文件违规涉及的一处位置，行号从 1 开始，EndLine 为最后一行（含）。
+type Location struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	EndLine int    `json:"end_line"`
+}
+
+// paragraphPrint 是段落指纹，供 duplicate_content 跨文件比较；只保存哈希，不保留全文。
+type paragraphPrint struct {
+	Sum     [sha256.Size]byte
+	Line    int
+	EndLine int
+	Snippet string
+}
+
+// duplicateKey 把行或段落规范化为比较用的文本：去掉所有空白（含段内换行），
+// 这样中文段落换行位置不同、英文多空格等差异都不影响比较。
+func duplicateKey(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+var htmlCommentLineRe = regexp.MustCompile(`^\s*<!--.*-->\s*$`)
+
+// duplicateLines 返回用于切分段落的行：只有 HTML 注释的行（如 syl-wc 指令）当作空行，
+// 紧贴在段落前的注释不会混进段落内容，指令也能按段落首行生效。
+func duplicateLines(lines []string) []string {
+	var out []string
+	for i, ln := range lines {
+		if !htmlCommentLineRe.MatchString(ln) {
+			continue
+		}
+		if out == nil {
+			out = append([]string(nil), lines...)
+		}
+		out[i] = ""
+	}
+	if out == nil {
+		return lines
+	}
+	return out
+}
+
+// duplicateComparable 判断规范化后的文本是否参与比较：去掉空白后不短于 minChars，且至少含一个字母或数字
+// （分隔线、表格对齐行等纯符号行不算重复）。
+func duplicateComparable(key string, minChars int) bool {
+	if key == "" || utf8.RuneCountInString(key) < minChars {
+		return false
+	}
+	return strings.IndexFunc(key, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0
+}
+
+// evaluateDuplicateLines 报告与前文某行内容相同的行。空行、标题与围栏代码块不参与比较，
+// 每个重复行各报一次，消息里指出首次出现的行。
+func evaluateDuplicateLines(path string, scope evalScope, rule config.DuplicateRule) []Violation {
+	if !rule.Enabled {
+		return nil
+	}
+	minChars := rule.MinCharsOr(defaultDuplicateLineChars)
+	lines := scope.Metrics.LinesText
+	first := map[string]int{}
+	violations := make([]Violation, 0)
+	for _, p := range textutil.SplitParagraphs(duplicateLines(lines)) {
+		for i := p.StartLine - 1; i < p.EndLine; i++ {
+			key := duplicateKey(lines[i])
+			if !duplicateComparable(key, minChars) {
+				continue
+			}
+			line := scope.StartLine + i
+			at, ok := first[key]
+			if !ok {
+				first[key] = line
+				continue
+			}
+			violations = append(violations, Violation{
+				RuleID:  "no_duplicate_lines",
+				Message: scopeMessage(scope, fmt.Sprintf("与第 %d 行重复", at)),
+				Path:    path,
+				Line:    line,
+				Column:  1,
+				Snippet: snippetLine(lines[i]),
+				Actual:  "duplicate",
+				Limit:   "unique",
+				Scope:   scope.Scope,
+			})
+		}
+	}
+	return violations
+}
+
+// evaluateDuplicateParagraphs 报告与前文某段内容相同的段落，比较时忽略段内换行与空白差异。
+func evaluateDuplicateParagraphs(path string, scope evalScope, rule config.DuplicateRule) []Violation {
+	if !rule.Enabled {
+		return nil
+	}
+	minChars := rule.MinCharsOr(defaultDuplicateParagraphChars)
+	lines := scope.Metrics.LinesText
+	first := map[string]int{}
+	violations := make([]Violation, 0)
+	for _, p := range textutil.SplitParagraphs(duplicateLines(lines)) {
+		key := duplicateKey(p.Text)
+		if !duplicateComparable(key, minChars) {
+			continue
+		}
+		line := scope.StartLine + p.StartLine - 1
+		at, ok := first[key]
+		if !ok {
+			first[key] = line
+			continue
+		}
+		v := Violation{
+			RuleID:  "no_duplicate_paragraphs",
+			Message: scopeMessage(scope, fmt.Sprintf("段落与第 %d 行开始的段落重复", at)),
+			Path:    path,
+			Line:    line,
+			Column:  1,
+			Snippet: snippetLine(lines[p.StartLine-1]),
+			Actual:  "duplicate",
+			Limit:   "unique",
+			Scope:   scope.Scope,
+		}
+		if p.EndLine > p.StartLine {
+			v.EndLine = scope.StartLine + p.EndLine - 1
+			v.EndColumn = utf8.RuneCountInString(lines[p.EndLine-1]) + 1
+			v.Snippet = snippetLines(lines, p.StartLine, p.EndLine)
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+func validateDuplicateRule(d config.DuplicateRule) error {
+	if d.MinChars != nil && *d.MinChars < 0 {
+		return fmt.Errorf("min_chars 不能为负数：%d", *d.MinChars)
+	}
+	return nil
+}
+
+// paragraphPrints 计算文件中参与 duplicate_content 比较的段落指纹。
+func paragraphPrints(lines []string, rule config.DuplicateRule) []paragraphPrint {
+	minChars := rule.MinCharsOr(defaultDuplicateParagraphChars)
+	out := make([]paragraphPrint, 0)
+	for _, p := range textutil.SplitParagraphs(duplicateLines(lines)) {
+		key := duplicateKey(p.Text)
+		if !duplicateComparable(key, minChars) {
+			continue
+		}
+		out = append(out, paragraphPrint{
+			Sum:     sha256.Sum256([]byte(key)),
+			Line:    p.StartLine,
+			EndLine: p.EndLine,
+			Snippet: snippetLine(lines[p.StartLine-1]),
+		})
+	}
+	return out
+}
+
+// duplicateContentViolations 按指纹把各文件的段落分组，出现在两个及以上文件中的段落每组报一条违规，
+// locations 列出所有位置（同一文件内的重复也列出）。paths 须已排序，分组按首次出现的位置排列。
+func duplicateContentViolations(paths []string, prints map[string][]paragraphPrint) []Violation {
+	type group struct {
+		Snippet   string
+		Locations []Location
+		Files     map[string]struct{}
+	}
+	groups := map[[sha256.Size]byte]*group{}
+	order := make([][sha256.Size]byte, 0)
+	for _, p := range paths {
+		for _, pp := range prints[p] {
+			g, ok := groups[pp.Sum]
+			if !ok {
+				g = &group{Snippet: pp.Snippet, Files: map[string]struct{}{}}
+				groups[pp.Sum] = g
+				order = append(order, pp.Sum)
+			}
+			g.Locations = append(g.Locations, Location{Path: p, Line: pp.Line, EndLine: pp.EndLine})
+			g.Files[p] = struct{}{}
+		}
+	}
+	violations := make([]Violation, 0)
+	for _, sum := range order {
+		g := groups[sum]
+		if len(g.Files) < 2 {
+			continue
+		}
+		first := g.Locations[0]
+		violations = append(violations, Violation{
+			RuleID:    "duplicate_content",
+			Message:   fmt.Sprintf("%d 个文件中出现相同段落（共 %d 处）", len(g.Files), len(g.Locations)),
+			Path:      first.Path,
+			Line:      first.Line,
+			Column:    1,
+			Snippet:   g.Snippet,
+			Actual:    len(g.Locations),
+			Limit:     1,
+			Scope:     "corpus",
+			Locations: g.Locations,
+		})
+	}
+	return violations
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"syl-wordcount/internal/textutil"
+)
+
+func parseAllowedLanguages(list []string) ([]string, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	supported := map[string]struct{}{}
+	for _, l := range textutil.SupportedLanguages {
+		supported[l] = struct{}{}
+	}
+	out := make([]string, 0, len(list))
+	for _, l := range list {
+		v := strings.ToLower(strings.TrimSpace(l))
+		if v == "" {
+			continue
+		}
+		if _, ok := supported[v]; !ok {
+			return nil, fmt.Errorf("不支持的语言代码：%s（可选：%s）", l, strings.Join(textutil.SupportedLanguages, ","))
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// evaluateAllowedLanguages 检查范围内识别出的主要语言是否在允许列表中；
+// 用在 section_rules 中即可对双语文档逐章节检查。无法识别语言时不报。
+func evaluateAllowedLanguages(path string, scope evalScope, allowed []string) []Violation {
+	if len(allowed) == 0 {
+		return nil
+	}
+	lang := scope.Metrics.Language
+	if lang == "" || lang == "unknown" {
+		return nil
+	}
+	for _, a := range allowed {
+		if a == lang {
+			return nil
+		}
+	}
+	return []Violation{scopeLevelViolation(path, scope, "allowed_languages", scopeMessage(scope, "语言不在允许范围"), lang, allowed)}
+}
@@ -0,0 +1,290 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+var (
+	urlSchemeRegex  = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.\-]+):`)
+	htmlAnchorRegex = regexp.MustCompile(`(?i)<[a-z][^>]*\s(?:id|name)\s*=\s*["']([^"']+)["']`)
+	attrAnchorRegex = regexp.MustCompile(`\{#([^\s}]+)\}`)
+)
+
+// evaluateLocalLinks 检查 Markdown 链接与图片：本地目标必须存在（相对当前文档解析），
+// #锚点必须对应目标 Markdown 文档的标题或显式 id；外部链接只做格式检查，不访问网络。
+func evaluateLocalLinks(fc FileContent, rules config.Rules) []Violation {
+	lines := fc.Metrics.LinesText
+	links := textutil.ExtractMarkdownLinks(lines)
+	defs := map[string]struct{}{}
+	for _, l := range links {
+		if l.Kind == textutil.LinkDefinition {
+			defs[textutil.NormalizeLinkLabel(l.Label)] = struct{}{}
+		}
+	}
+
+	anchors := map[string]map[string]struct{}{}
+	anchorsOf := func(target string) map[string]struct{} {
+		if a, ok := anchors[target]; ok {
+			return a
+		}
+		var a map[string]struct{}
+		if target == fc.Path {
+			a = documentAnchors(lines, rules.MarkdownHTMLHeadings)
+		} else if data, err := os.ReadFile(target); err == nil {
+			if dec, err := textutil.Decode(data); err == nil {
+				a = documentAnchors(strings.Split(normalize(dec.Text), "\n"), rules.MarkdownHTMLHeadings)
+			}
+		}
+		anchors[target] = a
+		return a
+	}
+
+	out := make([]Violation, 0)
+	for _, l := range links {
+		switch l.Kind {
+		case textutil.LinkShortcut:
+			continue
+		case textutil.LinkReference:
+			if _, ok := defs[textutil.NormalizeLinkLabel(l.Label)]; !ok {
+				out = append(out, linkViolation(fc.Path, lines, l, fmt.Sprintf("引用链接未定义：[%s]", l.Label), l.Label, "defined"))
+			}
+			continue
+		}
+		dest := strings.TrimSpace(l.Dest)
+		if dest == "" || dest == "#" {
+			continue
+		}
+		if m := urlSchemeRegex.FindStringSubmatch(dest); m != nil || strings.HasPrefix(dest, "//") {
+			if !validExternalURL(dest) {
+				out = append(out, linkViolation(fc.Path, lines, l, "外部链接格式无效："+dest, dest, "valid_url"))
+			}
+			continue
+		}
+
+		pathPart, fragment, _ := strings.Cut(dest, "#")
+		pathPart, _, _ = strings.Cut(pathPart, "?")
+		if p, err := url.PathUnescape(pathPart); err == nil {
+			pathPart = p
+		}
+		target := fc.Path
+		if pathPart != "" {
+			target = resolveLinkTarget(fc, pathPart)
+			if target == "" {
+				continue
+			}
+			info, err := os.Stat(target)
+			if err != nil {
+				out = append(out, linkViolation(fc.Path, lines, l, "链接目标不存在："+dest, dest, "exists"))
+				continue
+			}
+			if info.IsDir() {
+				continue
+			}
+		}
+		if fragment == "" || l.Image || !isMarkdownPath(target) {
+			continue
+		}
+		if f, err := url.PathUnescape(fragment); err == nil {
+			fragment = f
+		}
+		if _, ok := anchorsOf(target)[strings.ToLower(fragment)]; !ok {
+			out = append(out, linkViolation(fc.Path, lines, l, "链接锚点不存在："+dest, dest, "anchor_exists"))
+		}
+	}
+	return out
+}
+
+// resolveLinkTarget 把链接路径解析为绝对路径：以 / 开头的相对项目根目录（当前工作目录），其余相对当前文档。
+func resolveLinkTarget(fc FileContent, p string) string {
+	p = filepath.FromSlash(p)
+	if strings.HasPrefix(p, string(filepath.Separator)) {
+		root := projectRoot(fc)
+		if root == "" {
+			return ""
+		}
+		return filepath.Join(root, p)
+	}
+	return filepath.Join(filepath.Dir(fc.Path), p)
+}
+
+func projectRoot(fc FileContent) string {
+	if fc.RelPath == "" || strings.HasPrefix(fc.RelPath, "..") {
+		return ""
+	}
+	abs := filepath.ToSlash(fc.Path)
+	if !strings.HasSuffix(abs, "/"+fc.RelPath) {
+		return ""
+	}
+	return filepath.FromSlash(strings.TrimSuffix(abs, fc.RelPath))
+}
+
+func isMarkdownPath(p string) bool {
+	return syntaxByExt[strings.ToLower(filepath.Ext(p))] == SyntaxMarkdown
+}
+
+// documentAnchors 收集文档里可用的锚点（统一小写）：标题 slug、HTML id/name 属性与 {#id}。
+func documentAnchors(lines []string, htmlHeadings bool) map[string]struct{} {
+	heads := scanMarkdownHeadings(lines, htmlHeadings)
+	titles := make([]string, 0, len(heads))
+	for _, h := range heads {
+		titles = append(titles, h.Title)
+	}
+	out := map[string]struct{}{}
+	for _, s := range textutil.HeadingSlugs(titles) {
+		out[s] = struct{}{}
+	}
+	for _, ln := range lines {
+		for _, m := range htmlAnchorRegex.FindAllStringSubmatch(ln, -1) {
+			out[strings.ToLower(m[1])] = struct{}{}
+		}
+		for _, m := range attrAnchorRegex.FindAllStringSubmatch(ln, -1) {
+			out[strings.ToLower(m[1])] = struct{}{}
+		}
+	}
+	return out
+}
+
+// validExternalURL 只做语法检查：http/https/ftp 需要主机名，mailto 需要邮箱地址，其他协议不检查。
+func validExternalURL(dest string) bool {
+	if strings.ContainsAny(dest, " \t") {
+		return false
+	}
+	if strings.HasPrefix(dest, "//") {
+		dest = "https:" + dest
+	}
+	u, err := url.Parse(dest)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "ftp", "ftps":
+		host := u.Hostname()
+		return host != "" && !strings.HasPrefix(host, ".") && !strings.HasSuffix(host, "..")
+	case "mailto":
+		addr := u.Opaque
+		if addr == "" {
+			addr = u.Path
+		}
+		local, domain, ok := strings.Cut(addr, "@")
+		return ok && local != "" && domain != ""
+	}
+	return true
+}
+
+// linkViolation 生成断链违规：actual 为链接目标（引用链接为 id），limit 为期望（exists / anchor_exists / valid_url / defined）。
+func linkViolation(path string, lines []string, l textutil.MarkdownLink, msg string, actual, limit any) Violation {
+	return Violation{
+		RuleID:  "check_local_links",
+		Message: msg,
+		Path:    path,
+		Line:    l.Line,
+		Column:  l.Column,
+		Snippet: snippetLine(lines[l.Line-1]),
+		Actual:  actual,
+		Limit:   limit,
+		Scope:   "file",
+	}
+}
+
+// defaultGenericLinkTexts 是 forbid_generic_link_text 未配置 generic_link_texts 时使用的词表。
+var defaultGenericLinkTexts = []string{
+	"点击这里", "点击此处", "点此", "这里", "此处", "链接", "更多", "了解更多", "详情",
+	"click here", "here", "link", "this link", "more", "read more", "learn more",
+}
+
+// evaluateLinkTextRules 检查 Markdown 链接与图片的可访问性：图片替代文本、裸 URL、空链接文本与泛化链接文本。
+// 只看行内链接与完整/折叠引用链接，[文本] 简写形式不检查。
+func evaluateLinkTextRules(path string, scope evalScope, rules scopeRules) []Violation {
+	if !scope.Markdown || !(rules.RequireImageAlt || rules.ForbidBareURLs || rules.ForbidEmptyLinkText || rules.ForbidGenericLinkText) {
+		return nil
+	}
+	violations := make([]Violation, 0)
+	lines := scope.Metrics.LinesText
+	at := func(line, col int, ruleID, msg string, actual, limit any) Violation {
+		return Violation{
+			RuleID:  ruleID,
+			Message: scopeMessage(scope, msg),
+			Path:    path,
+			Line:    scope.StartLine + line - 1,
+			Column:  col,
+			Snippet: snippetLine(lines[line-1]),
+			Actual:  actual,
+			Limit:   limit,
+			Scope:   scope.Scope,
+		}
+	}
+
+	generic := map[string]struct{}{}
+	if rules.ForbidGenericLinkText {
+		words := rules.GenericLinkTexts
+		if len(words) == 0 {
+			words = defaultGenericLinkTexts
+		}
+		for _, w := range words {
+			generic[normalizeLinkText(w)] = struct{}{}
+		}
+	}
+
+	for _, l := range textutil.ExtractMarkdownLinks(lines) {
+		if l.Kind != textutil.LinkInline && l.Kind != textutil.LinkReference {
+			continue
+		}
+		text := strings.TrimSpace(l.Text)
+		if l.Image {
+			if rules.RequireImageAlt && text == "" {
+				violations = append(violations, at(l.Line, l.Column, "require_image_alt", "图片缺少替代文本（alt）", "", "non_empty"))
+			}
+			continue
+		}
+		if text == "" {
+			if rules.ForbidEmptyLinkText {
+				violations = append(violations, at(l.Line, l.Column, "forbid_empty_link_text", "链接文本为空", "", "non_empty"))
+			}
+			continue
+		}
+		if _, ok := generic[normalizeLinkText(text)]; ok {
+			violations = append(violations, at(l.Line, l.Column, "forbid_generic_link_text", "链接文本过于笼统，应说明链接去向："+text, text, "none"))
+		}
+	}
+
+	if rules.RequireImageAlt {
+		for _, img := range textutil.ExtractHTMLImages(lines) {
+			if !img.HasAlt {
+				violations = append(violations, at(img.Line, img.Column, "require_image_alt", "<img> 缺少 alt 属性", "", "non_empty"))
+			}
+		}
+	}
+
+	if rules.ForbidBareURLs {
+		for _, u := range textutil.FindBareURLs(lines) {
+			v := at(u.Line, u.Column, "forbid_bare_urls", "存在裸 URL，应写成链接："+u.URL, u.URL, "none")
+			if urlSchemeRegex.MatchString(u.URL) {
+				v.Suggestion = "<" + u.URL + ">"
+				v.Fix = &textutil.TextEdit{Line: v.Line, StartColumn: u.Column, EndColumn: u.EndColumn, Replacement: v.Suggestion}
+			}
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// normalizeLinkText 归一化链接文本用于泛化词比较：去掉强调符号与首尾标点，折叠空白并转小写。
+func normalizeLinkText(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '*' || r == '_' || r == '`' {
+			return -1
+		}
+		return r
+	}, s)
+	s = strings.TrimFunc(s, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsPunct(r) })
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
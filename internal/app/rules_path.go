@@ -0,0 +1,175 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"syl-wordcount/internal/config"
+)
+
+// 路径规则只看文件与目录的名称，不读取内容，违规的 scope 为 path。层级与名称检查都相对于
+// 文件所在的输入路径：输入是目录时从该目录算起，输入是单个文件时只看文件名本身。
+
+func hasPathRules(r config.Rules) bool {
+	return r.FilenamePattern != "" || r.MaxFilenameLength != nil || r.MaxPathDepth != nil ||
+		r.ForbidWhitespaceInNames || r.ForbidNonASCIINames
+}
+
+func hasDirectoryRules(r config.Rules) bool {
+	return r.ForbidWhitespaceInNames || r.ForbidNonASCIINames || len(r.RequiredFiles) > 0
+}
+
+func compilePathRules(r config.Rules) (*regexp.Regexp, []error) {
+	errs := make([]error, 0)
+	var pattern *regexp.Regexp
+	if r.FilenamePattern != "" {
+		re, err := regexp.Compile(r.FilenamePattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("filename_pattern 编译失败：%w", err))
+		} else {
+			pattern = re
+		}
+	}
+	if r.MaxFilenameLength != nil && *r.MaxFilenameLength <= 0 {
+		errs = append(errs, fmt.Errorf("max_filename_length 必须为正数：%d", *r.MaxFilenameLength))
+	}
+	if r.MaxPathDepth != nil && *r.MaxPathDepth <= 0 {
+		errs = append(errs, fmt.Errorf("max_path_depth 必须为正数：%d", *r.MaxPathDepth))
+	}
+	for i, rf := range r.RequiredFiles {
+		name := fmt.Sprintf("required_files[%d]", i)
+		if len(rf.Dirs) == 0 {
+			errs = append(errs, fmt.Errorf("%s.dirs 不能为空", name))
+		} else if err := validateGlobs(rf.Dirs, name+".dirs"); err != nil {
+			errs = append(errs, err)
+		}
+		if len(rf.Files) == 0 {
+			errs = append(errs, fmt.Errorf("%s.files 不能为空", name))
+		}
+		for _, f := range rf.Files {
+			if strings.TrimSpace(f) == "" || strings.ContainsAny(f, `/\`) {
+				errs = append(errs, fmt.Errorf("%s.files 只能写文件名：%q", name, f))
+			}
+		}
+	}
+	return pattern, errs
+}
+
+// inputRoot 返回包含 path 的最长输入目录；path 本身就是输入文件时返回其所在目录。
+func inputRoot(roots []string, path string) string {
+	best := ""
+	for _, r := range roots {
+		if r == path {
+			return filepath.Dir(path)
+		}
+		if strings.HasPrefix(path, strings.TrimSuffix(r, string(filepath.Separator))+string(filepath.Separator)) && len(r) > len(best) {
+			best = r
+		}
+	}
+	if best == "" {
+		return filepath.Dir(path)
+	}
+	return best
+}
+
+// pathDepth 是文件相对输入路径的层级数，文件名本身算一层：输入目录下的 a.md 为 1，guide/a.md 为 2。
+func pathDepth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return 1
+	}
+	return len(strings.Split(filepath.ToSlash(rel), "/"))
+}
+
+// EvaluatePath 检查单个文件的文件名与层级。跳过的二进制或超大文件同样会检查。
+func (rs *RuleSet) EvaluatePath(path, root string) []Violation {
+	r := rs.Rules
+	violations := make([]Violation, 0)
+	name := filepath.Base(path)
+	pathViolation := func(ruleID, msg string, actual, limit any) {
+		violations = append(violations, Violation{
+			RuleID:  ruleID,
+			Message: msg,
+			Path:    path,
+			Snippet: name,
+			Actual:  actual,
+			Limit:   limit,
+			Scope:   "path",
+		})
+	}
+	if rs.filenamePattern != nil && !rs.filenamePattern.MatchString(name) {
+		pathViolation("filename_pattern", "文件名不符合 filename_pattern", name, r.FilenamePattern)
+	}
+	if r.MaxFilenameLength != nil {
+		if n := utf8.RuneCountInString(name); n > *r.MaxFilenameLength {
+			pathViolation("max_filename_length", "文件名长度超出上限", n, *r.MaxFilenameLength)
+		}
+	}
+	if r.MaxPathDepth != nil {
+		if d := pathDepth(root, path); d > *r.MaxPathDepth {
+			pathViolation("max_path_depth", "路径层级超出上限", d, *r.MaxPathDepth)
+		}
+	}
+	if r.ForbidWhitespaceInNames && hasWhitespace(name) {
+		pathViolation("forbid_whitespace_in_names", "文件名包含空白字符", name, "no_whitespace")
+	}
+	if r.ForbidNonASCIINames && hasNonASCII(name) {
+		pathViolation("forbid_non_ascii_names", "文件名包含非 ASCII 字符", name, "ascii")
+	}
+	return violations
+}
+
+// evaluateDirectoryRules 检查输入目录下遍历到的每个目录（含空目录与文件全被忽略的目录）：输入目录
+// 以下的目录名按 forbid_whitespace_in_names / forbid_non_ascii_names 检查，required_files 对匹配 dirs
+// 的每个目录（含输入目录本身）检查必需文件是否存在。必需文件只看磁盘上是否存在，不受忽略规则影响。
+func evaluateDirectoryRules(r config.Rules, cwd string, roots, dirs []string) []Violation {
+	violations := make([]Violation, 0)
+	dirViolation := func(ruleID, dir, msg string, actual, limit any) {
+		violations = append(violations, Violation{
+			RuleID:  ruleID,
+			Message: msg,
+			Path:    dir,
+			Snippet: filepath.Base(dir),
+			Actual:  actual,
+			Limit:   limit,
+			Scope:   "path",
+		})
+	}
+	for _, d := range dirs {
+		if !containsString(roots, d) {
+			name := filepath.Base(d)
+			if r.ForbidWhitespaceInNames && hasWhitespace(name) {
+				dirViolation("forbid_whitespace_in_names", d, "目录名包含空白字符", name, "no_whitespace")
+			}
+			if r.ForbidNonASCIINames && hasNonASCII(name) {
+				dirViolation("forbid_non_ascii_names", d, "目录名包含非 ASCII 字符", name, "ascii")
+			}
+		}
+		fc := FileContent{Path: d, RelPath: relPath(cwd, d)}
+		for _, rf := range r.RequiredFiles {
+			if !matchFileGlobs(rf.Dirs, fc) {
+				continue
+			}
+			for _, f := range rf.Files {
+				if info, err := os.Stat(filepath.Join(d, f)); err == nil && !info.IsDir() {
+					continue
+				}
+				dirViolation("required_files", d, fmt.Sprintf("目录缺少必需文件：%s", f), "missing", f)
+			}
+		}
+	}
+	return violations
+}
+
+func hasWhitespace(name string) bool {
+	return strings.IndexFunc(name, unicode.IsSpace) >= 0
+}
+
+func hasNonASCII(name string) bool {
+	return strings.IndexFunc(name, func(c rune) bool { return c > unicode.MaxASCII }) >= 0
+}
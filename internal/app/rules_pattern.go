@@ -0,0 +1,257 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+type compiledPattern struct {
+	Source    string
+	Regex     *regexp.Regexp
+	Allow     []*regexp.Regexp
+	WholeWord bool
+	MinCount  *int
+	MaxCount  *int
+	Message   string
+	ID        string
+}
+
+// counted 表示该模式按命中次数判断（设置了 min_count 或 max_count）。
+func (p compiledPattern) counted() bool {
+	return p.MinCount != nil || p.MaxCount != nil
+}
+
+func (p compiledPattern) ruleID(def string) string {
+	if p.ID != "" {
+		return p.ID
+	}
+	return def
+}
+
+func (p compiledPattern) message(def string) string {
+	if p.Message != "" {
+		return p.Message
+	}
+	return def
+}
+
+// hits 返回所有有效命中：whole_word 要求词边界，被 allow 覆盖的命中去掉。
+func (p compiledPattern) hits(text string) [][]int {
+	out := make([][]int, 0)
+	var allowed [][]int
+	if len(p.Allow) > 0 {
+		for _, re := range p.Allow {
+			allowed = append(allowed, re.FindAllStringIndex(text, -1)...)
+		}
+	}
+	for _, idx := range p.Regex.FindAllStringIndex(text, -1) {
+		if p.WholeWord && !textutil.AtWordBoundary(text, idx[0], idx[1]) {
+			continue
+		}
+		if coveredBy(allowed, idx) {
+			continue
+		}
+		out = append(out, idx)
+	}
+	return out
+}
+
+func coveredBy(ranges [][]int, idx []int) bool {
+	for _, r := range ranges {
+		if r[0] <= idx[0] && idx[1] <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateForbiddenPatterns 默认每次命中记一次违规；设置了 min_count/max_count 时改为按命中次数判断，
+// 超出上限的违规指向第一个超出的命中。
+func evaluateForbiddenPatterns(path string, scope evalScope, rules []compiledPattern) []Violation {
+	if len(rules) == 0 {
+		return nil
+	}
+	normText := normalize(scope.Text)
+	lineOffsets := textutil.BuildLineOffsets(scope.Metrics.LinesText)
+	violations := make([]Violation, 0)
+	at := func(pr compiledPattern, idx []int, msg string, actual, limit any) Violation {
+		return patternHitViolation(path, scope, normText, lineOffsets, idx, pr.ruleID("forbidden_pattern"), msg, actual, limit)
+	}
+	for _, pr := range rules {
+		hits := pr.hits(normText)
+		if !pr.counted() {
+			for _, idx := range hits {
+				violations = append(violations, at(pr, idx, pr.message("命中禁止模式"), normText[idx[0]:idx[1]], pr.Source))
+			}
+			continue
+		}
+		violations = append(violations, patternCountViolations(path, scope, pr, "forbidden_pattern", hits, at)...)
+	}
+	return violations
+}
+
+// evaluateRequiredPatterns 默认要求至少命中一次；设置了 min_count/max_count 时按命中次数判断。
+func evaluateRequiredPatterns(path string, scope evalScope, rules []compiledPattern) []Violation {
+	if len(rules) == 0 {
+		return nil
+	}
+	normText := normalize(scope.Text)
+	lineOffsets := textutil.BuildLineOffsets(scope.Metrics.LinesText)
+	violations := make([]Violation, 0)
+	at := func(pr compiledPattern, idx []int, msg string, actual, limit any) Violation {
+		return patternHitViolation(path, scope, normText, lineOffsets, idx, pr.ruleID("required_pattern"), msg, actual, limit)
+	}
+	for _, pr := range rules {
+		hits := pr.hits(normText)
+		if !pr.counted() {
+			if len(hits) == 0 {
+				violations = append(violations, scopeLevelViolation(path, scope, pr.ruleID("required_pattern"), scopeMessage(scope, pr.message("缺少必需模式")), "not_found", pr.Source))
+			}
+			continue
+		}
+		violations = append(violations, patternCountViolations(path, scope, pr, "required_pattern", hits, at)...)
+	}
+	return violations
+}
+
+// patternHitViolation 构造指向一次命中的违规。命中跨行时带 EndLine/EndColumn（结束位置之后的列，
+// 末尾的换行不计），片段列出涉及的所有行；单行命中仍用命中处前后的上下文。
+func patternHitViolation(path string, scope evalScope, normText string, lineOffsets []int, idx []int, ruleID, msg string, actual, limit any) Violation {
+	lines := scope.Metrics.LinesText
+	pos := textutil.LineAndColumnByOffset(lines, lineOffsets, idx[0])
+	line := 0
+	if pos.Line > 0 {
+		line = scope.StartLine + pos.Line - 1
+	}
+	v := Violation{
+		RuleID:  ruleID,
+		Message: scopeMessage(scope, msg),
+		Path:    path,
+		Line:    line,
+		Column:  pos.Column,
+		Snippet: textutil.SnippetByRune(normText, idx[0], contextChars),
+		Actual:  actual,
+		Limit:   limit,
+		Scope:   scope.Scope,
+	}
+	end := idx[1]
+	if end > idx[0] && normText[end-1] == '\n' {
+		end--
+	}
+	endPos := textutil.LineAndColumnByOffset(lines, lineOffsets, end)
+	if pos.Line > 0 && endPos.Line > pos.Line {
+		v.EndLine = scope.StartLine + endPos.Line - 1
+		v.EndColumn = endPos.Column
+		v.Snippet = snippetLines(lines, pos.Line, endPos.Line)
+	}
+	return v
+}
+
+// patternCountViolations 按命中次数检查：少于 min_count 报在范围级别，多于 max_count 报在第一个超出的命中处。
+// actual 为命中次数，limit 为对应的上下限。
+func patternCountViolations(path string, scope evalScope, pr compiledPattern, defID string, hits [][]int, at func(compiledPattern, []int, string, any, any) Violation) []Violation {
+	n := len(hits)
+	if pr.MinCount != nil && n < *pr.MinCount {
+		msg := pr.message(fmt.Sprintf("模式命中次数低于下限：%s", pr.Source))
+		return []Violation{scopeLevelViolation(path, scope, pr.ruleID(defID), scopeMessage(scope, msg), n, *pr.MinCount)}
+	}
+	if pr.MaxCount != nil && n > *pr.MaxCount {
+		msg := pr.message(fmt.Sprintf("模式命中次数超出上限：%s", pr.Source))
+		return []Violation{at(pr, hits[*pr.MaxCount], msg, n, *pr.MaxCount)}
+	}
+	return nil
+}
+
+func compilePatternRules(list []config.PatternRule, kind string) ([]compiledPattern, []error) {
+	out := make([]compiledPattern, 0, len(list))
+	errs := make([]error, 0)
+	for i, pr := range list {
+		if strings.TrimSpace(pr.Pattern) == "" {
+			continue
+		}
+		name := fmt.Sprintf("%s[%d]", kind, i)
+		rx, err := compileRule(pr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s 编译失败：%w", name, err))
+			continue
+		}
+		cp := compiledPattern{
+			Source:    pr.Pattern,
+			Regex:     rx,
+			WholeWord: pr.WholeWord,
+			MinCount:  pr.MinCount,
+			MaxCount:  pr.MaxCount,
+			Message:   strings.TrimSpace(pr.Message),
+			ID:        strings.TrimSpace(pr.ID),
+		}
+		if err := validatePatternCounts(pr); err != nil {
+			errs = append(errs, fmt.Errorf("%s 配置错误：%w", name, err))
+			continue
+		}
+		bad := false
+		for k, a := range pr.Allow {
+			re, err := textutil.CompilePattern(patternFlags(pr)+a, patternCaseSensitive(pr))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s.allow[%d] 编译失败：%w", name, k, err))
+				bad = true
+				continue
+			}
+			cp.Allow = append(cp.Allow, re)
+		}
+		if bad {
+			continue
+		}
+		out = append(out, cp)
+	}
+	return out, errs
+}
+
+func validatePatternCounts(pr config.PatternRule) error {
+	if pr.MinCount != nil && *pr.MinCount < 0 {
+		return fmt.Errorf("min_count 不能为负数：%d", *pr.MinCount)
+	}
+	if pr.MaxCount != nil && *pr.MaxCount < 0 {
+		return fmt.Errorf("max_count 不能为负数：%d", *pr.MaxCount)
+	}
+	if pr.MinCount != nil && pr.MaxCount != nil && *pr.MinCount > *pr.MaxCount {
+		return fmt.Errorf("min_count（%d）不能大于 max_count（%d）", *pr.MinCount, *pr.MaxCount)
+	}
+	if strings.ContainsAny(strings.TrimSpace(pr.ID), " \t\n") {
+		return fmt.Errorf("id 不能包含空白：%q", pr.ID)
+	}
+	return nil
+}
+
+func patternCaseSensitive(pr config.PatternRule) bool {
+	if pr.CaseSensitive != nil {
+		return *pr.CaseSensitive
+	}
+	return true
+}
+
+func compileRule(pr config.PatternRule) (*regexp.Regexp, error) {
+	p := pr.Pattern
+	if pr.Literal {
+		p = regexp.QuoteMeta(p)
+	}
+	return textutil.CompilePattern(patternFlags(pr)+p, patternCaseSensitive(pr))
+}
+
+// patternFlags 把 multiline/dotall 选项转成 RE2 的内联标志，allow 正则使用同样的标志。
+func patternFlags(pr config.PatternRule) string {
+	flags := ""
+	if pr.Multiline {
+		flags += "m"
+	}
+	if pr.DotAll {
+		flags += "s"
+	}
+	if flags == "" {
+		return ""
+	}
+	return "(?" + flags + ")"
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"syl-wordcount/internal/config"
+)
+
+type sectionSelector struct {
+	Contains string
+	Equals   string
+	Regex    *regexp.Regexp
+	MinLevel int
+	MaxLevel int
+	Files    []string
+	First    bool
+}
+
+func compileSectionSelector(sr config.SectionRule, i int) (sectionSelector, error) {
+	sel := sectionSelector{
+		Contains: strings.TrimSpace(sr.HeadingContains),
+		Equals:   strings.TrimSpace(sr.HeadingEquals),
+		MinLevel: sr.MinLevel,
+		MaxLevel: sr.MaxLevel,
+		Files:    sr.Files,
+	}
+	if p := strings.TrimSpace(sr.HeadingRegex); p != "" {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return sel, fmt.Errorf("section_rules[%d].heading_regex 编译失败：%w", i, err)
+		}
+		sel.Regex = re
+	}
+	if sr.Level != 0 {
+		if sr.MinLevel != 0 || sr.MaxLevel != 0 {
+			return sel, fmt.Errorf("section_rules[%d] 的 level 不能与 min_level/max_level 同时设置", i)
+		}
+		sel.MinLevel, sel.MaxLevel = sr.Level, sr.Level
+	}
+	for _, lv := range []int{sel.MinLevel, sel.MaxLevel} {
+		if lv < 0 || lv > 6 {
+			return sel, fmt.Errorf("section_rules[%d] 标题级别必须在 1~6 之间：%d", i, lv)
+		}
+	}
+	if sel.MinLevel != 0 && sel.MaxLevel != 0 && sel.MinLevel > sel.MaxLevel {
+		return sel, fmt.Errorf("section_rules[%d] 的 min_level 不能大于 max_level", i)
+	}
+	if err := validateGlobs(sel.Files, fmt.Sprintf("section_rules[%d].files", i)); err != nil {
+		return sel, err
+	}
+	switch strings.ToLower(strings.TrimSpace(sr.Match)) {
+	case "", "all":
+	case "first":
+		sel.First = true
+	default:
+		return sel, fmt.Errorf("section_rules[%d].match 只支持 first/all：%s", i, sr.Match)
+	}
+	if sel.Contains == "" && sel.Equals == "" && sel.Regex == nil && sel.MinLevel == 0 && sel.MaxLevel == 0 {
+		return sel, fmt.Errorf("section_rules[%d] 缺少标题条件（heading_contains/heading_regex/heading_equals 或 level/min_level/max_level）", i)
+	}
+	return sel, nil
+}
+
+func (s sectionSelector) matchesFile(fc FileContent) bool {
+	return matchFileGlobs(s.Files, fc)
+}
+
+// matchFileGlobs 判断文件是否在 globs 范围内：依次匹配绝对路径、相对 CWD 的路径，
+// 不含 "/" 的 glob 还会匹配文件名。globs 为空时视为全部命中。
+func matchFileGlobs(globs []string, fc FileContent) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	abs := filepath.ToSlash(fc.Path)
+	rel := filepath.ToSlash(fc.RelPath)
+	base := filepath.Base(fc.Path)
+	for _, f := range globs {
+		if ok, _ := doublestar.Match(f, abs); ok {
+			return true
+		}
+		if rel != "" {
+			if ok, _ := doublestar.Match(f, rel); ok {
+				return true
+			}
+		}
+		if !strings.Contains(f, "/") {
+			if ok, _ := doublestar.Match(f, base); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s sectionSelector) matchesHeading(sec docSection) bool {
+	if s.MinLevel != 0 && sec.Level < s.MinLevel {
+		return false
+	}
+	if s.MaxLevel != 0 && sec.Level > s.MaxLevel {
+		return false
+	}
+	if s.Contains != "" && !strings.Contains(sec.Heading, s.Contains) {
+		return false
+	}
+	if s.Equals != "" && sec.Heading != s.Equals {
+		return false
+	}
+	if s.Regex != nil && !s.Regex.MatchString(sec.Heading) {
+		return false
+	}
+	return true
+}
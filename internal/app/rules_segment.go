@@ -0,0 +1,102 @@
+package app
+
+import (
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+// evaluateSegmentRules 检查句子与段落长度（违规指向句子/段落的起始位置）以及可读性；
+// 可读性按范围内识别出的语言选择算法，无法评分时不报。
+func evaluateSegmentRules(path string, scope evalScope, rules scopeRules) []Violation {
+	violations := make([]Violation, 0)
+	lines := scope.Metrics.LinesText
+
+	if rules.MaxSentenceChars != nil {
+		for _, s := range textutil.SplitSentences(lines) {
+			if s.Chars <= *rules.MaxSentenceChars {
+				continue
+			}
+			violations = append(violations, Violation{
+				RuleID:  "max_sentence_chars",
+				Message: scopeMessage(scope, "句子长度超出上限"),
+				Path:    path,
+				Line:    scope.StartLine + s.Line - 1,
+				Column:  s.Column,
+				Snippet: snippetLine(s.Text),
+				Actual:  s.Chars,
+				Limit:   *rules.MaxSentenceChars,
+				Scope:   scope.Scope,
+			})
+		}
+	}
+
+	if rules.MaxParagraphChars != nil || rules.MaxParagraphLines != nil {
+		for _, p := range textutil.SplitParagraphs(lines) {
+			if rules.MaxParagraphChars != nil && p.Chars > *rules.MaxParagraphChars {
+				violations = append(violations, paragraphViolation(path, scope, p, "max_paragraph_chars", "段落字符数超出上限", p.Chars, *rules.MaxParagraphChars))
+			}
+			if rules.MaxParagraphLines != nil && p.Lines > *rules.MaxParagraphLines {
+				violations = append(violations, paragraphViolation(path, scope, p, "max_paragraph_lines", "段落行数超出上限", p.Lines, *rules.MaxParagraphLines))
+			}
+		}
+	}
+
+	if rules.MinReadability != nil || rules.MaxGradeLevel != nil {
+		if rd, ok := textutil.ComputeReadability(lines, scope.Metrics.Language); ok {
+			if rules.MinReadability != nil && rd.ReadingEase < *rules.MinReadability {
+				violations = append(violations, scopeLevelViolation(path, scope, "min_readability", scopeMessage(scope, "可读性评分低于下限"), rd.ReadingEase, *rules.MinReadability))
+			}
+			if rules.MaxGradeLevel != nil && rd.GradeLevel > *rules.MaxGradeLevel {
+				violations = append(violations, scopeLevelViolation(path, scope, "max_grade_level", scopeMessage(scope, "阅读年级超出上限"), rd.GradeLevel, *rules.MaxGradeLevel))
+			}
+		}
+	}
+
+	return violations
+}
+
+func paragraphViolation(path string, scope evalScope, p textutil.Paragraph, ruleID, msg string, actual, limit int) Violation {
+	first := scope.Metrics.LinesText[p.StartLine-1]
+	col := 1
+	for _, r := range first {
+		if r != ' ' && r != '\t' && r != '　' {
+			break
+		}
+		col++
+	}
+	return Violation{
+		RuleID:  ruleID,
+		Message: scopeMessage(scope, msg),
+		Path:    path,
+		Line:    scope.StartLine + p.StartLine - 1,
+		Column:  col,
+		Snippet: snippetLine(first),
+		Actual:  actual,
+		Limit:   limit,
+		Scope:   scope.Scope,
+	}
+}
+
+func readingSpeedFromConfig(rs config.ReadingSpeed) textutil.ReadingSpeed {
+	return textutil.ReadingSpeed{
+		WordsPerMinute:         rs.WordsPerMinute,
+		CharsPerMinute:         rs.CharsPerMinute,
+		SpeakingWordsPerMinute: rs.SpeakingWordsPerMinute,
+		SpeakingCharsPerMinute: rs.SpeakingCharsPerMinute,
+	}.WithDefaults()
+}
+
+func evaluateReadingTime(path string, scope evalScope, cr compiledScopeRules) []Violation {
+	if cr.MaxReadingSecs <= 0 && cr.MaxSpeakingSecs <= 0 {
+		return nil
+	}
+	violations := make([]Violation, 0)
+	rt := textutil.EstimateReadingTime(scope.Text, cr.Rules.ReadingSpeed)
+	if cr.MaxReadingSecs > 0 && rt.ReadingSeconds > cr.MaxReadingSecs {
+		violations = append(violations, scopeLevelViolation(path, scope, "max_reading_time", scopeMessage(scope, "预计阅读时长超出上限"), rt.ReadingSeconds, cr.MaxReadingSecs))
+	}
+	if cr.MaxSpeakingSecs > 0 && rt.SpeakingSeconds > cr.MaxSpeakingSecs {
+		violations = append(violations, scopeLevelViolation(path, scope, "max_speaking_time", scopeMessage(scope, "预计朗读时长超出上限"), rt.SpeakingSeconds, cr.MaxSpeakingSecs))
+	}
+	return violations
+}
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+// compileSensitiveRules 校验 secrets / pii 配置；两组都没开启时返回 nil。
+func compileSensitiveRules(r config.Rules) (*textutil.SensitiveOptions, []error) {
+	errs := make([]error, 0)
+	for _, g := range []struct {
+		name string
+		rule config.SensitiveRule
+	}{{"secrets", r.Secrets}, {"pii", r.PII}} {
+		known := textutil.SensitiveRuleIDs(g.name)
+		for _, id := range g.rule.Exclude {
+			if !containsString(known, id) {
+				errs = append(errs, fmt.Errorf("%s.exclude 包含未知规则：%s（可选：%s）", g.name, id, strings.Join(known, ", ")))
+			}
+		}
+	}
+	if r.PII.MinEntropy != nil || r.PII.MinLength != nil {
+		errs = append(errs, fmt.Errorf("pii 不支持 min_entropy / min_length，它们只用于 secrets.high_entropy"))
+	}
+	if v := r.Secrets.MinEntropy; v != nil && *v <= 0 {
+		errs = append(errs, fmt.Errorf("secrets.min_entropy 必须为正数：%v", *v))
+	}
+	if v := r.Secrets.MinLength; v != nil && *v < 8 {
+		errs = append(errs, fmt.Errorf("secrets.min_length 不能小于 8：%d", *v))
+	}
+	if !r.Secrets.Enabled && !r.PII.Enabled {
+		return nil, errs
+	}
+	opts := &textutil.SensitiveOptions{Secrets: r.Secrets.Enabled, PII: r.PII.Enabled}
+	opts.Exclude = append(append(opts.Exclude, r.Secrets.Exclude...), r.PII.Exclude...)
+	if r.Secrets.MinEntropy != nil {
+		opts.MinEntropy = *r.Secrets.MinEntropy
+	}
+	if r.Secrets.MinLength != nil {
+		opts.MinEntropyLength = *r.Secrets.MinLength
+	}
+	return opts, errs
+}
+
+// evaluateSensitive 把 FindSensitive 在规范化文本 normText 上的命中转成违规。actual 与 snippet 中的命中
+// 内容都经过遮盖，输出的 NDJSON 本身不会泄露原值；snippet 里同一上下文的其他命中也一并遮盖。
+func evaluateSensitive(path string, normText string, lines []string, matches []textutil.SensitiveMatch) []Violation {
+	if len(matches) == 0 {
+		return nil
+	}
+	var masked strings.Builder
+	maskedStart := make([]int, len(matches))
+	prev := 0
+	for i, m := range matches {
+		masked.WriteString(normText[prev:m.Start])
+		maskedStart[i] = masked.Len()
+		masked.WriteString(textutil.MaskSensitive(m.RuleID, normText[m.Start:m.End]))
+		prev = m.End
+	}
+	masked.WriteString(normText[prev:])
+	maskedText := masked.String()
+
+	lineOffsets := textutil.BuildLineOffsets(lines)
+	violations := make([]Violation, 0, len(matches))
+	for i, m := range matches {
+		pos := textutil.LineAndColumnByOffset(lines, lineOffsets, m.Start)
+		v := Violation{
+			RuleID:  m.RuleID,
+			Message: "疑似敏感信息：" + textutil.SensitiveLabel(m.RuleID),
+			Path:    path,
+			Line:    pos.Line,
+			Column:  pos.Column,
+			Snippet: textutil.SnippetByRune(maskedText, maskedStart[i], contextChars),
+			Actual:  textutil.MaskSensitive(m.RuleID, normText[m.Start:m.End]),
+			Limit:   "none",
+			Scope:   "file",
+		}
+		if end := textutil.LineAndColumnByOffset(lines, lineOffsets, m.End); end.Line > pos.Line {
+			v.EndLine = end.Line
+			v.EndColumn = end.Column
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveMasker 遮盖其他规则违规里带出的敏感内容。snippet 可能截断在命中中间，
+// 所以除了完整命中，也遮盖首尾残留的命中片段。
+type sensitiveMasker struct {
+	pieces []maskPiece
+}
+
+type maskPiece struct {
+	Value  string
+	Masked string
+}
+
+// minMaskFragment 是片段遮盖的最短字节数，更短的残留不足以还原原值。
+const minMaskFragment = 4
+
+// newSensitiveMasker 由文件中的敏感命中构建遮盖表；没有命中时返回 nil。跨行的命中（私钥）按行拆开，
+// 除首行外整行遮盖。
+func newSensitiveMasker(normText string, matches []textutil.SensitiveMatch) *sensitiveMasker {
+	if len(matches) == 0 {
+		return nil
+	}
+	m := &sensitiveMasker{}
+	for _, sm := range matches {
+		value := normText[sm.Start:sm.End]
+		if !strings.Contains(value, "\n") {
+			m.pieces = append(m.pieces, maskPiece{Value: value, Masked: textutil.MaskSensitive(sm.RuleID, value)})
+			continue
+		}
+		for i, part := range strings.Split(value, "\n") {
+			if i == 0 || strings.TrimSpace(part) == "" {
+				continue
+			}
+			m.pieces = append(m.pieces, maskPiece{Value: part, Masked: stars(part)})
+		}
+	}
+	return m
+}
+
+// apply 遮盖违规的 snippet、actual 与 suggestion；fix 不改，否则 --fix 会把遮盖结果写回文件。
+func (m *sensitiveMasker) apply(v *Violation) {
+	v.Snippet = m.mask(v.Snippet)
+	if s, ok := v.Actual.(string); ok {
+		v.Actual = m.mask(s)
+	}
+	v.Suggestion = m.mask(v.Suggestion)
+}
+
+func (m *sensitiveMasker) mask(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, p := range m.pieces {
+		s = strings.ReplaceAll(s, p.Value, p.Masked)
+	}
+	for _, p := range m.pieces {
+		v := p.Value
+		if len(s) >= minMaskFragment && strings.Contains(v, s) {
+			return stars(s)
+		}
+		body := strings.TrimPrefix(s, "...")
+		head := s[:len(s)-len(body)]
+		for k := len(v) - 1; k >= minMaskFragment; k-- {
+			if frag := v[len(v)-k:]; strings.HasPrefix(body, frag) && utf8.ValidString(frag) {
+				body = stars(frag) + body[k:]
+				break
+			}
+		}
+		s = head + body
+		body = strings.TrimSuffix(s, "...")
+		tail := s[len(body):]
+		for k := len(v) - 1; k >= minMaskFragment; k-- {
+			if frag := v[:k]; strings.HasSuffix(body, frag) && utf8.ValidString(frag) {
+				body = body[:len(body)-k] + stars(frag)
+				break
+			}
+		}
+		s = body + tail
+	}
+	return s
+}
+
+func stars(s string) string {
+	return strings.Repeat("*", utf8.RuneCountInString(s))
+}
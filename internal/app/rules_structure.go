@@ -0,0 +1,184 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"syl-wordcount/internal/config"
+)
+
+func hasStructureRule(r config.Rules) bool {
+	return len(r.RequiredSections.Headings) > 0 || len(r.ForbiddenSections) > 0 || r.MaxHeadingDepth != nil ||
+		r.NoSkippedHeadingLevels || r.SingleH1 || r.UniqueHeadings
+}
+
+// evaluateStructureRules 检查文档结构，只用于有明确文档语法的文件（见 splitterSet.Document）。违规指向对应标题行；
+// 缺少的必需章节指向建议插入的位置（下一个已有必需章节的标题行，或上一个的章节末尾之后）。
+func evaluateStructureRules(fc FileContent, rules config.Rules, sections []docSection) []Violation {
+	if !hasStructureRule(rules) {
+		return nil
+	}
+	violations := make([]Violation, 0)
+	lines := fc.Metrics.LinesText
+
+	rs := rules.RequiredSections
+	if len(rs.Headings) > 0 && matchFileGlobs(rs.Files, fc) {
+		violations = append(violations, checkRequiredSections(fc.Path, lines, rs, sections)...)
+	}
+
+	forbidden := map[string]struct{}{}
+	for _, h := range rules.ForbiddenSections {
+		if h = strings.TrimSpace(h); h != "" {
+			forbidden[h] = struct{}{}
+		}
+	}
+
+	seen := map[string]int{}
+	prevLevel := 0
+	h1 := 0
+	for _, sec := range sections {
+		if _, ok := forbidden[sec.Heading]; ok {
+			violations = append(violations, headingViolation(fc.Path, lines, sec, "forbidden_sections", fmt.Sprintf("出现禁止的章节[%s]", sec.Heading), sec.Heading, "none"))
+		}
+		if rules.MaxHeadingDepth != nil && sec.Level > *rules.MaxHeadingDepth {
+			violations = append(violations, headingViolation(fc.Path, lines, sec, "max_heading_depth", "标题层级超出上限", sec.Level, *rules.MaxHeadingDepth))
+		}
+		if rules.NoSkippedHeadingLevels && prevLevel > 0 && sec.Level > prevLevel+1 {
+			violations = append(violations, headingViolation(fc.Path, lines, sec, "no_skipped_heading_levels", fmt.Sprintf("标题层级跳级：H%d 后直接出现 H%d", prevLevel, sec.Level), sec.Level, prevLevel+1))
+		}
+		prevLevel = sec.Level
+		if sec.Level == 1 {
+			h1++
+			if rules.SingleH1 && h1 > 1 {
+				violations = append(violations, headingViolation(fc.Path, lines, sec, "single_h1", "文档只能有一个一级标题", h1, 1))
+			}
+		}
+		if rules.UniqueHeadings {
+			if first, ok := seen[sec.Heading]; ok {
+				violations = append(violations, headingViolation(fc.Path, lines, sec, "unique_headings", fmt.Sprintf("标题重复（首次出现在第 %d 行）", first), sec.Heading, "unique"))
+			} else {
+				seen[sec.Heading] = sec.HeadingLine
+			}
+		}
+	}
+	return violations
+}
+
+// validateStructureRules 检查文档结构规则的配置，在编译规则时调用一次。
+func validateStructureRules(rules config.Rules) []error {
+	errs := make([]error, 0)
+	rs := rules.RequiredSections
+	if len(rs.Headings) == 0 {
+		return errs
+	}
+	if rs.Level < 0 || rs.Level > 6 {
+		errs = append(errs, fmt.Errorf("required_sections.level 必须在 1~6 之间：%d", rs.Level))
+	}
+	if err := validateGlobs(rs.Files, "required_sections.files"); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func checkRequiredSections(path string, lines []string, rs config.RequiredSections, sections []docSection) []Violation {
+	violations := make([]Violation, 0)
+	want := make([]string, 0, len(rs.Headings))
+	for _, h := range rs.Headings {
+		if h = strings.TrimSpace(h); h != "" {
+			want = append(want, h)
+		}
+	}
+	// 每个必需章节取第一次出现的标题
+	found := make([]*docSection, len(want))
+	for i, h := range want {
+		for j := range sections {
+			if sections[j].Heading == h {
+				found[i] = &sections[j]
+				break
+			}
+		}
+	}
+
+	for i, h := range want {
+		sec := found[i]
+		if sec == nil {
+			line := requiredInsertLine(found, i, len(lines))
+			snippet := ""
+			if line <= len(lines) {
+				snippet = snippetLine(lines[line-1])
+			}
+			violations = append(violations, Violation{
+				RuleID:  "required_sections",
+				Message: fmt.Sprintf("缺少必需章节[%s]", h),
+				Path:    path,
+				Line:    line,
+				Column:  1,
+				Snippet: snippet,
+				Actual:  "missing",
+				Limit:   h,
+				Scope:   "file",
+			})
+			continue
+		}
+		if rs.Level > 0 && sec.Level != rs.Level {
+			violations = append(violations, headingViolation(path, lines, *sec, "required_sections", fmt.Sprintf("必需章节[%s]应为 H%d", h, rs.Level), sec.Level, rs.Level))
+		}
+	}
+
+	if rs.Ordered {
+		maxLine := 0
+		maxHeading := ""
+		for i, sec := range found {
+			if sec == nil {
+				continue
+			}
+			if sec.HeadingLine < maxLine {
+				violations = append(violations, headingViolation(path, lines, *sec, "required_sections_order", fmt.Sprintf("必需章节[%s]应位于[%s]之前", maxHeading, want[i]), want[i], maxHeading))
+				continue
+			}
+			maxLine = sec.HeadingLine
+			maxHeading = want[i]
+		}
+	}
+	return violations
+}
+
+// requiredInsertLine 为缺失的第 i 个必需章节给出插入行：优先放在后面第一个已有必需章节之前，
+// 否则放在前面最近一个已有必需章节的末尾之后，都没有时放在文件末尾。
+func requiredInsertLine(found []*docSection, i, totalLines int) int {
+	for j := i + 1; j < len(found); j++ {
+		if found[j] != nil {
+			return found[j].HeadingLine
+		}
+	}
+	for j := i - 1; j >= 0; j-- {
+		if found[j] != nil {
+			return found[j].EndLine + 1
+		}
+	}
+	return totalLines + 1
+}
+
+func headingViolation(path string, lines []string, sec docSection, ruleID, msg string, actual, limit any) Violation {
+	return Violation{
+		RuleID:  ruleID,
+		Message: msg,
+		Path:    path,
+		Line:    sec.HeadingLine,
+		Column:  1,
+		Snippet: snippetLine(lines[sec.HeadingLine-1]),
+		Actual:  actual,
+		Limit:   limit,
+		Scope:   "file",
+	}
+}
+
+func validateGlobs(globs []string, field string) error {
+	for _, g := range globs {
+		if !doublestar.ValidatePattern(g) {
+			return fmt.Errorf("%s 不是有效的 glob：%s", field, g)
+		}
+	}
+	return nil
+}
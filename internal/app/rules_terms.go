@@ -0,0 +1,204 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+// compiledTerm 是一条编译后的术语：Regex 匹配任一 avoid 写法，Exceptions 覆盖到的命中不报。
+type compiledTerm struct {
+	Use        string
+	Regex      *regexp.Regexp
+	Exceptions []*regexp.Regexp
+	// CaseOnly 表示 avoid 与 use 只有大小写差异（如 github → GitHub），替换时不做大小写跟随。
+	CaseOnly bool
+}
+
+type termMatch struct {
+	Term       *compiledTerm
+	Start, End int
+}
+
+func compileTerminology(t config.Terminology, prefix string) ([]compiledTerm, []error) {
+	out := make([]compiledTerm, 0, len(t.Terms))
+	errs := make([]error, 0)
+	for i, e := range t.Terms {
+		name := fmt.Sprintf("%s.terms[%d]", prefix, i)
+		use := strings.TrimSpace(e.Use)
+		if use == "" {
+			errs = append(errs, fmt.Errorf("%s 配置错误：use 不能为空", name))
+			continue
+		}
+		caseSensitive := t.CaseSensitive
+		if e.CaseSensitive != nil {
+			caseSensitive = *e.CaseSensitive
+		}
+		flags := "(?i)"
+		if caseSensitive {
+			flags = ""
+		}
+
+		avoid := make([]string, 0, len(e.Avoid))
+		caseOnly := false
+		bad := false
+		for _, a := range e.Avoid {
+			a = strings.TrimSpace(a)
+			switch {
+			case a == "":
+				errs = append(errs, fmt.Errorf("%s.avoid 配置错误：不能包含空字符串", name))
+				bad = true
+			case a == use:
+				errs = append(errs, fmt.Errorf("%s.avoid 配置错误：“%s”与 use 相同", name, a))
+				bad = true
+			default:
+				if strings.EqualFold(a, use) {
+					caseOnly = true
+				}
+				avoid = append(avoid, a)
+			}
+		}
+		if bad {
+			continue
+		}
+		if len(avoid) == 0 {
+			errs = append(errs, fmt.Errorf("%s.avoid 配置错误：至少需要一个写法", name))
+			continue
+		}
+		// 长写法优先，避免 "log" 抢先匹配 "log in"
+		sort.SliceStable(avoid, func(a, b int) bool { return len(avoid[a]) > len(avoid[b]) })
+		alts := make([]string, 0, len(avoid))
+		for _, a := range avoid {
+			alts = append(alts, termPattern(a))
+		}
+		re, err := regexp.Compile(flags + "(?:" + strings.Join(alts, "|") + ")")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s 编译失败：%w", name, err))
+			continue
+		}
+		ct := compiledTerm{Use: use, Regex: re, CaseOnly: caseOnly}
+		for _, ex := range e.Exceptions {
+			if ex = strings.TrimSpace(ex); ex == "" {
+				continue
+			}
+			ct.Exceptions = append(ct.Exceptions, regexp.MustCompile(flags+termPattern(ex)))
+		}
+		out = append(out, ct)
+	}
+	return out, errs
+}
+
+// termPattern 把术语写法转成正则：字面匹配，词间任意空白都算一个空格。
+func termPattern(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = regexp.QuoteMeta(w)
+	}
+	return strings.Join(words, `[ \t]+`)
+}
+
+// evaluateTerminology 按术语表检查不推荐的写法，给出推荐写法并附带自动修复。
+// 拉丁字母写法要求词边界（avoid 为 "login" 时不会命中 "logins"），中文写法按子串匹配；
+// Markdown 文档跳过代码块与行内代码。
+func evaluateTerminology(path string, scope evalScope, terms []compiledTerm) []Violation {
+	if len(terms) == 0 {
+		return nil
+	}
+	lines := scope.Metrics.LinesText
+	if scope.Markdown {
+		lines = textutil.MaskMarkdownCode(lines)
+	}
+	violations := make([]Violation, 0)
+	for i, ln := range lines {
+		if ln == "" {
+			continue
+		}
+		matches := make([]termMatch, 0)
+		for k := range terms {
+			t := &terms[k]
+			for _, loc := range t.Regex.FindAllStringIndex(ln, -1) {
+				if ln[loc[0]:loc[1]] == t.Use || !textutil.AtWordBoundary(ln, loc[0], loc[1]) || inTermException(ln, loc[0], loc[1], t.Exceptions) {
+					continue
+				}
+				matches = append(matches, termMatch{Term: t, Start: loc[0], End: loc[1]})
+			}
+		}
+		sort.SliceStable(matches, func(a, b int) bool {
+			if matches[a].Start != matches[b].Start {
+				return matches[a].Start < matches[b].Start
+			}
+			return matches[a].End > matches[b].End
+		})
+
+		line := scope.StartLine + i
+		last := 0
+		for _, m := range matches {
+			if m.Start < last {
+				continue
+			}
+			last = m.End
+			got := ln[m.Start:m.End]
+			want := m.Term.Use
+			if !m.Term.CaseOnly {
+				want = matchCase(got, want)
+			}
+			col := utf8.RuneCountInString(ln[:m.Start]) + 1
+			violations = append(violations, Violation{
+				RuleID:     "terminology",
+				Message:    scopeMessage(scope, fmt.Sprintf("术语不规范：“%s”应写作“%s”", got, want)),
+				Path:       path,
+				Line:       line,
+				Column:     col,
+				Snippet:    snippetLine(scope.Metrics.LinesText[i]),
+				Actual:     got,
+				Limit:      m.Term.Use,
+				Scope:      scope.Scope,
+				Suggestion: want,
+				Fix:        &textutil.TextEdit{Line: line, StartColumn: col, EndColumn: col + utf8.RuneCountInString(got), Replacement: want},
+			})
+		}
+	}
+	return violations
+}
+
+func inTermException(s string, start, end int, exceptions []*regexp.Regexp) bool {
+	for _, re := range exceptions {
+		for _, loc := range re.FindAllStringIndex(s, -1) {
+			if loc[0] <= start && end <= loc[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchCase 让替换词跟随原文的大小写风格：全大写则全大写，首字母大写则首字母大写。
+func matchCase(got, use string) string {
+	letters, upper := 0, 0
+	for _, r := range got {
+		if unicode.IsLetter(r) && unicode.IsUpper(r) != unicode.IsLower(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters == 0 {
+		return use
+	}
+	if letters > 1 && upper == letters {
+		return strings.ToUpper(use)
+	}
+	first, _ := utf8.DecodeRuneInString(got)
+	u, size := utf8.DecodeRuneInString(use)
+	if unicode.IsUpper(first) && unicode.IsLower(u) {
+		return string(unicode.ToUpper(u)) + use[size:]
+	}
+	return use
+}
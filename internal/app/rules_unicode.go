@@ -0,0 +1,201 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+type codepointRange struct {
+	Source string
+	Lo     rune
+	Hi     rune
+}
+
+var zeroWidthRunes = map[rune]struct{}{
+	'\u200b': {}, // ZERO WIDTH SPACE
+	'\u200c': {}, // ZERO WIDTH NON-JOINER
+	'\u200d': {}, // ZERO WIDTH JOINER
+	'\u2060': {}, // WORD JOINER
+	'\ufeff': {}, // ZERO WIDTH NO-BREAK SPACE / BOM
+}
+
+var bidiControlRunes = map[rune]struct{}{
+	'\u061c': {}, // ARABIC LETTER MARK
+	'\u200e': {}, // LEFT-TO-RIGHT MARK
+	'\u200f': {}, // RIGHT-TO-LEFT MARK
+	'\u202a': {}, '\u202b': {}, '\u202c': {}, '\u202d': {}, '\u202e': {},
+	'\u2066': {}, '\u2067': {}, '\u2068': {}, '\u2069': {},
+}
+
+var nbspRunes = map[rune]struct{}{
+	'\u00a0': {}, // NO-BREAK SPACE
+	'\u2007': {}, // FIGURE SPACE
+	'\u202f': {}, // NARROW NO-BREAK SPACE
+}
+
+func evaluateUnicodeRules(path string, scope evalScope, cr compiledScopeRules) []Violation {
+	violations := make([]Violation, 0)
+	rules := cr.Rules
+
+	if rules.NoZeroWidthChars {
+		violations = append(violations, runeViolations(path, scope, "no_zero_width_chars", "存在零宽字符", func(r rune, line, b int) (any, bool) {
+			if _, ok := zeroWidthRunes[r]; !ok {
+				return nil, false
+			}
+			// 文件开头的 BOM 属于编码标记，不算违规
+			if r == '\ufeff' && line == 1 && b == 0 {
+				return nil, false
+			}
+			return "none", true
+		})...)
+	}
+	if rules.NoBidiControls {
+		violations = append(violations, runeViolations(path, scope, "no_bidi_controls", "存在双向文本控制字符", func(r rune, _, _ int) (any, bool) {
+			_, ok := bidiControlRunes[r]
+			return "none", ok
+		})...)
+	}
+	if rules.NoNBSP {
+		violations = append(violations, runeViolations(path, scope, "no_nbsp", "存在不间断空格", func(r rune, _, _ int) (any, bool) {
+			_, ok := nbspRunes[r]
+			return "none", ok
+		})...)
+	}
+	if len(cr.Codepoints) > 0 {
+		violations = append(violations, runeViolations(path, scope, "forbidden_codepoint", "存在禁止的码位", func(r rune, _, _ int) (any, bool) {
+			for _, cp := range cr.Codepoints {
+				if r >= cp.Lo && r <= cp.Hi {
+					return cp.Source, true
+				}
+			}
+			return nil, false
+		})...)
+	}
+	if rules.RequireNFC {
+		violations = append(violations, evaluateNFC(path, scope)...)
+	}
+	return violations
+}
+
+// runeViolations 逐字符扫描作用域内的每一行，match 返回 limit 与是否命中。
+func runeViolations(path string, scope evalScope, ruleID, msg string, match func(r rune, line, byteOffset int) (any, bool)) []Violation {
+	violations := make([]Violation, 0)
+	for i, ln := range scope.Metrics.LinesText {
+		line := scope.StartLine + i
+		for b := 0; b < len(ln); {
+			r, size := utf8.DecodeRuneInString(ln[b:])
+			if limit, ok := match(r, line, b); ok {
+				violations = append(violations, Violation{
+					RuleID:  ruleID,
+					Message: scopeMessage(scope, msg),
+					Path:    path,
+					Line:    line,
+					Column:  utf8.RuneCountInString(ln[:b]) + 1,
+					Snippet: snippetLine(ln),
+					Actual:  formatCodepoint(r),
+					Limit:   limit,
+					Scope:   scope.Scope,
+				})
+			}
+			b += size
+		}
+	}
+	return violations
+}
+
+func evaluateNFC(path string, scope evalScope) []Violation {
+	violations := make([]Violation, 0)
+	for i, ln := range scope.Metrics.LinesText {
+		if norm.NFC.IsNormalString(ln) {
+			continue
+		}
+		for b := 0; b < len(ln); {
+			n := norm.NFC.NextBoundaryInString(ln[b:], true)
+			if n <= 0 {
+				break
+			}
+			seg := ln[b : b+n]
+			if !norm.NFC.IsNormalString(seg) {
+				violations = append(violations, Violation{
+					RuleID:  "require_nfc",
+					Message: scopeMessage(scope, "文本未使用 NFC 规范化形式"),
+					Path:    path,
+					Line:    scope.StartLine + i,
+					Column:  utf8.RuneCountInString(ln[:b]) + 1,
+					Snippet: snippetLine(ln),
+					Actual:  formatCodepoints(seg),
+					Limit:   "NFC",
+					Scope:   scope.Scope,
+				})
+			}
+			b += n
+		}
+	}
+	return violations
+}
+
+func parseCodepointRanges(list []string, kind string) ([]codepointRange, []error) {
+	out := make([]codepointRange, 0, len(list))
+	errs := make([]error, 0)
+	for _, raw := range list {
+		src := strings.TrimSpace(raw)
+		if src == "" {
+			continue
+		}
+		lo, hi, err := parseCodepointRange(src)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s 配置错误：%w", kind, err))
+			continue
+		}
+		out = append(out, codepointRange{Source: src, Lo: lo, Hi: hi})
+	}
+	return out, errs
+}
+
+// parseCodepointRange 解析 "U+200B" 或 "U+2000-U+200F" 形式的码位（范围）。
+func parseCodepointRange(s string) (rune, rune, error) {
+	loStr, hiStr, isRange := strings.Cut(s, "-")
+	lo, err := parseCodepoint(loStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("无效码位：%s", s)
+	}
+	if !isRange {
+		return lo, lo, nil
+	}
+	hi, err := parseCodepoint(hiStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("无效码位：%s", s)
+	}
+	if hi < lo {
+		return 0, 0, fmt.Errorf("码位范围起点大于终点：%s", s)
+	}
+	return lo, hi, nil
+}
+
+func parseCodepoint(s string) (rune, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	if !strings.HasPrefix(v, "U+") {
+		return 0, fmt.Errorf("missing U+ prefix")
+	}
+	n, err := strconv.ParseUint(strings.TrimPrefix(v, "U+"), 16, 32)
+	if err != nil || n > utf8.MaxRune {
+		return 0, fmt.Errorf("invalid code point")
+	}
+	return rune(n), nil
+}
+
+func formatCodepoint(r rune) string {
+	return fmt.Sprintf("U+%04X", r)
+}
+
+func formatCodepoints(s string) string {
+	parts := make([]string, 0, len(s))
+	for _, r := range s {
+		parts = append(parts, formatCodepoint(r))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+// newWordMatcher 为词表构建自动机；词表为空时返回 nil。大小写默认敏感，与 forbidden_patterns 一致。
+func newWordMatcher(w config.WordListRule) *textutil.WordMatcher {
+	if len(w.Words) == 0 {
+		return nil
+	}
+	caseSensitive := true
+	if w.CaseSensitive != nil {
+		caseSensitive = *w.CaseSensitive
+	}
+	return textutil.NewWordMatcher(w.Words, caseSensitive, w.WholeWord)
+}
+
+// evaluateForbiddenWords 报告禁用词表的每次命中，格式与 forbidden_pattern 相同；同一位置取最长的词。
+func evaluateForbiddenWords(path string, scope evalScope, m *textutil.WordMatcher) []Violation {
+	if m == nil {
+		return nil
+	}
+	normText := normalize(scope.Text)
+	lineOffsets := textutil.BuildLineOffsets(scope.Metrics.LinesText)
+	words := m.Words()
+	violations := make([]Violation, 0)
+	for _, wm := range m.FindAll(normText) {
+		pos := textutil.LineAndColumnByOffset(scope.Metrics.LinesText, lineOffsets, wm.Start)
+		line := 0
+		if pos.Line > 0 {
+			line = scope.StartLine + pos.Line - 1
+		}
+		violations = append(violations, Violation{
+			RuleID:  "forbidden_word",
+			Message: scopeMessage(scope, "命中禁用词"),
+			Path:    path,
+			Line:    line,
+			Column:  pos.Column,
+			Snippet: textutil.SnippetByRune(normText, wm.Start, contextChars),
+			Actual:  normText[wm.Start:wm.End],
+			Limit:   words[wm.Word],
+			Scope:   scope.Scope,
+		})
+	}
+	return violations
+}
+
+// evaluateRequiredWords 要求必需词表中的每个词都至少出现一次，缺一个报一条。
+func evaluateRequiredWords(path string, scope evalScope, m *textutil.WordMatcher) []Violation {
+	if m == nil {
+		return nil
+	}
+	violations := make([]Violation, 0)
+	words := m.Words()
+	for i, ok := range m.Present(normalize(scope.Text)) {
+		if !ok {
+			violations = append(violations, scopeLevelViolation(path, scope, "required_word", scopeMessage(scope, "缺少必需词"), "not_found", words[i]))
+		}
+	}
+	return violations
+}
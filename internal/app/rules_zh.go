@@ -0,0 +1,217 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+var halfToFullwidthPunct = map[rune]rune{
+	',': '，',
+	'.': '。',
+	';': '；',
+	':': '：',
+	'!': '！',
+	'?': '？',
+}
+
+var duplicateCheckedPunct = map[rune]struct{}{
+	'。': {}, '，': {}, '、': {}, '；': {}, '：': {}, '！': {}, '？': {},
+	',': {}, ';': {}, ':': {},
+}
+
+func evaluateZhTypography(path string, scope evalScope, z config.ZhTypographyRules) []Violation {
+	if !z.Any() {
+		return nil
+	}
+	violations := make([]Violation, 0)
+	for i, ln := range scope.Metrics.LinesText {
+		line := scope.StartLine + i
+		runes := []rune(ln)
+		if z.CJKLatinSpace {
+			violations = append(violations, checkCJKLatinSpace(path, scope, line, ln, runes)...)
+		}
+		if z.FullwidthPunctuation || z.NoHalfwidthCommaAfterHan {
+			violations = append(violations, checkPunctuationWidth(path, scope, line, ln, runes, z)...)
+		}
+		if z.NoDuplicatePunctuation {
+			violations = append(violations, checkDuplicatePunctuation(path, scope, line, ln, runes)...)
+		}
+	}
+	return violations
+}
+
+func checkCJKLatinSpace(path string, scope evalScope, line int, ln string, runes []rune) []Violation {
+	violations := make([]Violation, 0)
+	for j := 1; j < len(runes); j++ {
+		prev, cur := runes[j-1], runes[j]
+		if !(isHan(prev) && isLatinOrDigit(cur)) && !(isLatinOrDigit(prev) && isHan(cur)) {
+			continue
+		}
+		col := j + 1
+		violations = append(violations, Violation{
+			RuleID:  "zh_typography.cjk_latin_space",
+			Message: scopeMessage(scope, "中文与英文/数字之间缺少空格"),
+			Path:    path,
+			Line:    line,
+			Column:  col,
+			Snippet: snippetLine(ln),
+			Actual:  string(runes[j-1 : j+1]),
+			Limit:   "space",
+			Scope:   scope.Scope,
+			Fix:     &textutil.TextEdit{Line: line, StartColumn: col, EndColumn: col, Replacement: " "},
+		})
+	}
+	return violations
+}
+
+func checkPunctuationWidth(path string, scope evalScope, line int, ln string, runes []rune, z config.ZhTypographyRules) []Violation {
+	violations := make([]Violation, 0)
+	for j := 1; j < len(runes); j++ {
+		full, ok := halfToFullwidthPunct[runes[j]]
+		if !ok || !isHan(runes[j-1]) {
+			continue
+		}
+		// 跳过标点后的空格，全角标点自带间距
+		k := j + 1
+		for k < len(runes) && runes[k] == ' ' {
+			k++
+		}
+		ruleID := "zh_typography.fullwidth_punctuation"
+		msg := "中文语境中应使用全角标点"
+		if runes[j] == ',' && z.NoHalfwidthCommaAfterHan {
+			ruleID = "zh_typography.no_halfwidth_comma_after_han"
+			msg = "中文字符后不应使用半角逗号"
+		} else {
+			if !z.FullwidthPunctuation {
+				continue
+			}
+			// 只在句子语境内替换，避免误伤 "文件.md"、"链接:https://..." 等写法
+			if k < len(runes) && !isHan(runes[k]) && !isCJKPunct(runes[k]) {
+				continue
+			}
+		}
+		end := j + 2
+		if k < len(runes) {
+			end = k + 1
+		}
+		violations = append(violations, Violation{
+			RuleID:  ruleID,
+			Message: scopeMessage(scope, msg),
+			Path:    path,
+			Line:    line,
+			Column:  j + 1,
+			Snippet: snippetLine(ln),
+			Actual:  string(runes[j]),
+			Limit:   string(full),
+			Scope:   scope.Scope,
+			Fix:     &textutil.TextEdit{Line: line, StartColumn: j + 1, EndColumn: end, Replacement: string(full)},
+		})
+	}
+	return violations
+}
+
+func checkDuplicatePunctuation(path string, scope evalScope, line int, ln string, runes []rune) []Violation {
+	violations := make([]Violation, 0)
+	for j := 0; j < len(runes); {
+		if _, ok := duplicateCheckedPunct[runes[j]]; !ok {
+			j++
+			continue
+		}
+		k := j + 1
+		for k < len(runes) && runes[k] == runes[j] {
+			k++
+		}
+		if k-j > 1 {
+			violations = append(violations, Violation{
+				RuleID:  "zh_typography.no_duplicate_punctuation",
+				Message: scopeMessage(scope, "标点符号重复"),
+				Path:    path,
+				Line:    line,
+				Column:  j + 1,
+				Snippet: snippetLine(ln),
+				Actual:  string(runes[j:k]),
+				Limit:   string(runes[j]),
+				Scope:   scope.Scope,
+			})
+		}
+		j = k
+	}
+	return violations
+}
+
+func isHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+func isLatinOrDigit(r rune) bool {
+	if r <= unicode.MaxASCII {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	// 全角拉丁字母本身带间距，不要求补空格
+	return r < 0xFF00 && unicode.Is(unicode.Latin, r)
+}
+
+func isCJKPunct(r rune) bool {
+	if !unicode.IsPunct(r) {
+		return false
+	}
+	return (r >= 0x3000 && r <= 0x303F) || (r >= 0xFF00 && r <= 0xFFEF)
+}
+
+func parseChineseVariant(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "":
+		return "", nil
+	case "hans", "zh-hans":
+		return textutil.ZhHans, nil
+	case "hant", "zh-hant":
+		return textutil.ZhHant, nil
+	default:
+		return "", fmt.Errorf("只支持 hans/hant：%s", v)
+	}
+}
+
+// evaluateChineseVariant 标出不属于期望体系（简体/繁体）的字，并给出另一体系的写法。
+func evaluateChineseVariant(path string, scope evalScope, want string) []Violation {
+	if want == "" {
+		return nil
+	}
+	label := "简体"
+	if want == textutil.ZhHant {
+		label = "繁体"
+	}
+	violations := make([]Violation, 0)
+	for i, ln := range scope.Metrics.LinesText {
+		line := scope.StartLine + i
+		col := 0
+		for _, r := range ln {
+			col++
+			got := textutil.ZhVariantOf(r)
+			if got == "" || got == want {
+				continue
+			}
+			counterparts := textutil.ZhCounterparts(r)
+			v := Violation{
+				RuleID:     "chinese_variant",
+				Message:    scopeMessage(scope, fmt.Sprintf("%s文本中混入了非%s字", label, label)),
+				Path:       path,
+				Line:       line,
+				Column:     col,
+				Snippet:    snippetLine(ln),
+				Actual:     string(r),
+				Limit:      want,
+				Scope:      scope.Scope,
+				Suggestion: strings.Join(counterparts, "/"),
+			}
+			if len(counterparts) == 1 {
+				v.Fix = &textutil.TextEdit{Line: line, StartColumn: col, EndColumn: col + 1, Replacement: counterparts[0]}
+			}
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
@@ -0,0 +1,257 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+// RuleSet 是编译后的规则集：正则、词表、章节选择器、章节语法与文件大小上限都在 CompileRules 里处理一次，
+// 之后只读，可被多个 goroutine 并发用于任意文件。
+type RuleSet struct {
+	Rules config.Rules
+
+	extensions      map[string]struct{}
+	maxFileSize     int64
+	filenamePattern *regexp.Regexp
+	sensitive       *textutil.SensitiveOptions // secrets / pii 都没开启时为 nil
+	speed           textutil.ReadingSpeed
+	splitters       *splitterSet // section_syntax 配置错误时为 nil，不切分章节
+	global          *compiledScopeRules
+	structure       config.Rules // 结构规则；required_sections 配置错误时去掉该项
+	sections        []compiledSectionRule
+}
+
+type compiledSectionRule struct {
+	Index    int
+	Selector sectionSelector
+	Rules    compiledScopeRules
+}
+
+// CompileRules 编译规则并返回全部配置错误。即使有错误也会返回可用的 RuleSet，出错的规则被跳过。
+func CompileRules(rules config.Rules) (*RuleSet, []error) {
+	errs := make([]error, 0)
+	rs := &RuleSet{
+		Rules:     rules,
+		speed:     readingSpeedFromConfig(rules.ReadingSpeed),
+		structure: rules,
+	}
+
+	if len(rules.AllowedExtensions) > 0 {
+		rs.extensions = map[string]struct{}{}
+		for _, a := range rules.AllowedExtensions {
+			rs.extensions[strings.ToLower(strings.TrimSpace(a))] = struct{}{}
+		}
+	}
+
+	if s := strings.TrimSpace(rules.MaxFileSize); s != "" {
+		maxBytes, err := config.ParseSizeToBytes(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("max_file_size 配置错误：%w", err))
+		} else {
+			rs.maxFileSize = maxBytes
+		}
+	}
+
+	splitters, err := newSplitterSet(rules)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		rs.splitters = splitters
+	}
+
+	globalSR := scopeRulesFromGlobal(rules)
+	globalSR.ReadingSpeed = rs.speed
+	if hasAnyScopeRule(globalSR) {
+		compiled, cErrs := compileScopeRules(globalSR, "")
+		errs = append(errs, cErrs...)
+		rs.global = &compiled
+	}
+
+	if err := validateDuplicateRule(rules.DuplicateContent); err != nil {
+		errs = append(errs, fmt.Errorf("duplicate_content 配置错误：%w", err))
+	}
+
+	errs = append(errs, validateCorpusRules(rules)...)
+
+	pattern, pErrs := compilePathRules(rules)
+	errs = append(errs, pErrs...)
+	rs.filenamePattern = pattern
+
+	sensitive, sErrs := compileSensitiveRules(rules)
+	errs = append(errs, sErrs...)
+	rs.sensitive = sensitive
+
+	if sErrs := validateStructureRules(rules); len(sErrs) > 0 {
+		errs = append(errs, sErrs...)
+		rs.structure.RequiredSections = config.RequiredSections{}
+	}
+
+	for i, sr := range rules.SectionRules {
+		sel, err := compileSectionSelector(sr, i)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		srScope := scopeRulesFromSection(sr.Rules)
+		srScope.ReadingSpeed = rs.speed
+		if !hasAnyScopeRule(srScope) {
+			errs = append(errs, fmt.Errorf("section_rules[%d].rules 至少要设置一条规则", i))
+			continue
+		}
+		compiled, cErrs := compileScopeRules(srScope, fmt.Sprintf("section_rules[%d].rules.", i))
+		errs = append(errs, cErrs...)
+		rs.sections = append(rs.sections, compiledSectionRule{Index: i, Selector: sel, Rules: compiled})
+	}
+	return rs, errs
+}
+
+// splitterFor 返回文件使用的章节切分器；section_syntax 配置错误时为 nil。
+func (rs *RuleSet) splitterFor(path string) sectionSplitter {
+	if rs.splitters == nil {
+		return nil
+	}
+	return rs.splitters.For(path)
+}
+
+// Evaluation 是单个文件的检查结果。Violations 已去掉被行内指令抑制的违规，Suppressed 为被抑制的条数，
+// UnusedDisables 为没有抑制任何违规的指令，只在开启 --report-unused-disables 时输出。
+type Evaluation struct {
+	Violations     []Violation
+	Warnings       []Warning
+	Suppressed     int
+	UnusedDisables []Warning
+
+	paragraphs []paragraphPrint // duplicate_content 的段落指纹，跨文件比较在 Run 中进行
+}
+
+// Evaluate 检查单个文件，返回违规、不影响退出码的提示与行内指令的抑制结果。
+func (rs *RuleSet) Evaluate(fc FileContent) Evaluation {
+	violations := make([]Violation, 0)
+	warnings := make([]Warning, 0)
+	rules := rs.Rules
+
+	if rs.extensions != nil {
+		ext := strings.ToLower(filepath.Ext(fc.Path))
+		if _, ok := rs.extensions[ext]; !ok {
+			violations = append(violations, fileLevel(fc.Path, "allowed_extensions", "文件扩展名不在允许范围", ext, rules.AllowedExtensions))
+		}
+	}
+
+	if rs.maxFileSize > 0 && int64(len(fc.Data)) > rs.maxFileSize {
+		violations = append(violations, fileLevel(fc.Path, "max_file_size", "文件大小超出上限", len(fc.Data), rs.maxFileSize))
+	}
+
+	var sections []docSection
+	splitter := rs.splitterFor(fc.Path)
+	if splitter != nil {
+		sections = collectSections(fc.Metrics.LinesText, splitter)
+	}
+	// 未知扩展名只是借用 Markdown 切分器，代码块屏蔽、链接检查等 Markdown 专属处理只用于明确的 Markdown 文件
+	_, isMarkdown := splitter.(markdownSplitter)
+	isMarkdown = isMarkdown && rs.splitters.Document(fc.Path)
+
+	if rs.global != nil {
+		fileScope := evalScope{
+			Scope:     "file",
+			Text:      fc.Text,
+			Metrics:   fc.Metrics,
+			StartLine: 1,
+			Markdown:  isMarkdown,
+		}
+		violations = append(violations, evaluateScope(fc.Path, fileScope, *rs.global)...)
+	}
+
+	if rs.splitters != nil && rs.splitters.Document(fc.Path) {
+		violations = append(violations, evaluateStructureRules(fc, rs.structure, sections)...)
+	}
+	if rules.CheckLocalLinks && isMarkdown {
+		violations = append(violations, evaluateLocalLinks(fc, rules)...)
+	}
+
+	for _, sr := range rs.sections {
+		if !sr.Selector.matchesFile(fc) {
+			continue
+		}
+		matched := 0
+		for _, sec := range sections {
+			if !sr.Selector.matchesHeading(sec) {
+				continue
+			}
+			matched++
+			scope := evalScope{
+				Scope:     "section",
+				Label:     sec.Heading,
+				LabelLine: sec.HeadingLine,
+				Text:      sec.Text,
+				Metrics:   sec.Metrics,
+				StartLine: sec.StartLine,
+				Markdown:  isMarkdown,
+			}
+			violations = append(violations, evaluateScope(fc.Path, scope, sr.Rules)...)
+			if sr.Selector.First {
+				break
+			}
+		}
+		if matched == 0 && len(sections) > 0 {
+			warnings = append(warnings, Warning{
+				Code:   "section_rule_unmatched",
+				Path:   fc.Path,
+				Detail: fmt.Sprintf("section_rules[%d] 没有匹配到任何标题", sr.Index),
+			})
+		}
+	}
+
+	var masker *sensitiveMasker
+	if rs.sensitive != nil {
+		normText := normalize(fc.Text)
+		matches := textutil.FindSensitive(normText, *rs.sensitive)
+		// 其他规则的 snippet / actual 可能带出密钥原文，先遮盖再追加 secrets / pii 自己的违规。
+		if masker = newSensitiveMasker(normText, matches); masker != nil {
+			for i := range violations {
+				masker.apply(&violations[i])
+			}
+		}
+		violations = append(violations, evaluateSensitive(fc.Path, normText, fc.Metrics.LinesText, matches)...)
+	}
+
+	ev := Evaluation{Violations: violations, Warnings: warnings}
+	var directives []*disableDirective
+	if strings.Contains(fc.Text, "syl-wc-") {
+		directives = parseDirectives(fc.Metrics.LinesText, isMarkdown)
+	}
+	if rules.DuplicateContent.Enabled {
+		ev.paragraphs = filterParagraphPrints(paragraphPrints(fc.Metrics.LinesText, rules.DuplicateContent), directives)
+		if masker != nil {
+			for i := range ev.paragraphs {
+				ev.paragraphs[i].Snippet = masker.mask(ev.paragraphs[i].Snippet)
+			}
+		}
+	}
+	if len(directives) > 0 {
+		var unused []*disableDirective
+		ev.Violations, ev.Suppressed, unused = applyDirectives(violations, directives)
+		for _, d := range unused {
+			ev.UnusedDisables = append(ev.UnusedDisables, Warning{
+				Code:   "unused_disable_directive",
+				Path:   fc.Path,
+				Line:   d.Line,
+				Detail: fmt.Sprintf("%s 没有抑制任何违规", d.Text),
+			})
+		}
+	}
+	return ev
+}
+
+// joinConfigErrors 把编译规则时的多条配置错误合成一条消息。
+func joinConfigErrors(errs []error) string {
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "；")
+}
@@ -21,6 +21,12 @@ type fileResult struct {
 	HasInputErr  bool
 	Skipped      bool
 	Processed    bool
+	Fixed        int
+	Suppressed   int
+	Paragraphs   []paragraphPrint
+	Similar      *similarInput
+	Chars        int
+	Redacted     []byte
 	RuleHit      map[string]struct{}
 }
 
@@ -43,10 +49,21 @@ func Run(opts Options) (Result, error) {
 	if opts.MaxFileSizeBytes <= 0 {
 		opts.MaxFileSizeBytes = 10 * 1024 * 1024
 	}
+	if opts.Mode == ModeRedact {
+		if opts.Mask == "" {
+			opts.Mask = DefaultRedactMask
+		}
+		if opts.OutDir != "" && !filepath.IsAbs(opts.OutDir) {
+			opts.OutDir = filepath.Join(opts.CWD, opts.OutDir)
+		}
+	}
+	if opts.SimilarityThreshold < 0 || opts.SimilarityThreshold > 1 {
+		return res, &ArgErr{Msg: fmt.Sprintf("相似度阈值必须在 0~1 之间：%v", opts.SimilarityThreshold)}
+	}
 
 	var cfg RuntimeConfig
 	configPathForMeta := opts.ConfigPath
-	if opts.Mode == ModeCheck {
+	if opts.Mode == ModeCheck || opts.Mode == ModeRedact {
 		rules, source, err := config.LoadRulesForCheck(opts.ConfigPath)
 		if err != nil {
 			return res, &ConfigErr{Msg: err.Error()}
@@ -60,6 +77,15 @@ func Run(opts Options) (Result, error) {
 		}
 		cfg = RuntimeConfig{Rules: loaded.Rules}
 	}
+	ruleSet, err := compileRuntimeRules(opts.Mode, cfg.Rules)
+	if err != nil {
+		return res, err
+	}
+	cfg.RuleSet = ruleSet
+	cfg.Roots = NormalizePaths(opts.Paths, opts.CWD)
+	if opts.Mode == ModeRedact && !hasRedactRules(ruleSet) {
+		return res, &ConfigErr{Msg: "redact 需要规则：请配置 forbidden_patterns，或开启 secrets / pii"}
+	}
 
 	meta := map[string]any{
 		"type":             "meta",
@@ -72,8 +98,17 @@ func Run(opts Options) (Result, error) {
 		"output_format":    opts.Format,
 		"follow_symlinks":  false,
 		"max_file_size":    opts.MaxFileSizeBytes,
+		"fix":              opts.Fix,
 		"exit_code_policy": map[string]int{"ok": 0, "violation": 1, "arg_error": 2, "input_error": 3, "config_error": 4, "internal_error": 5},
 	}
+	if opts.Mode == ModeRedact {
+		meta["out_dir"] = opts.OutDir
+		meta["mask"] = opts.Mask
+	}
+	if opts.DetectSimilar {
+		meta["detect_similar"] = true
+		meta["similarity_threshold"] = opts.SimilarityThreshold
+	}
 	res.Events = append(res.Events, meta)
 
 	scanRes := scan.Collect(scan.Options{
@@ -96,10 +131,23 @@ func Run(opts Options) (Result, error) {
 				res.Summary.Errors++
 			}
 		}
-		res.Events = append(res.Events, buildSummary(opts.Mode, res.Summary, decideExitCode(res)))
+		res.Events = append(res.Events, buildSummary(opts, res.Summary, decideExitCode(res)))
 		return res, nil
 	}
 
+	if opts.Mode == ModeRedact {
+		if opts.OutDir == "" && len(paths) != 1 {
+			return res, &ArgErr{Msg: fmt.Sprintf("没有 --out-dir 时只能输入单个文件（当前扫描到 %d 个），脱敏结果写到标准输出", len(paths))}
+		}
+		if opts.OutDir != "" {
+			outPaths, err := redactOutputs(cfg.Roots, paths, opts.OutDir)
+			if err != nil {
+				return res, &ArgErr{Msg: err.Error()}
+			}
+			cfg.OutPaths = outPaths
+		}
+	}
+
 	jobs := opts.Jobs
 	if jobs > len(paths) {
 		jobs = len(paths)
@@ -141,6 +189,11 @@ func Run(opts Options) (Result, error) {
 		if fr.Skipped {
 			res.Summary.Skipped++
 		}
+		res.Summary.Fixed += fr.Fixed
+		if fr.Redacted != nil {
+			res.Redacted = fr.Redacted
+		}
+		res.Summary.Suppressed += fr.Suppressed
 		if fr.HasViolation {
 			res.HasViolation = true
 		}
@@ -155,6 +208,63 @@ func Run(opts Options) (Result, error) {
 		}
 	}
 
+	if opts.Mode == ModeStats && opts.DetectSimilar {
+		inputs := make([]similarInput, 0, len(paths))
+		for _, p := range paths {
+			if in := byPath[p].Similar; in != nil {
+				inputs = append(inputs, *in)
+			}
+		}
+		groups := similarGroupEvents(inputs, opts.SimilarityThreshold)
+		res.Summary.Similar = len(groups)
+		res.Events = append(res.Events, groups...)
+	}
+
+	if opts.Mode == ModeCheck && cfg.RuleSet.Rules.DuplicateContent.Enabled {
+		prints := map[string][]paragraphPrint{}
+		for _, p := range paths {
+			prints[p] = byPath[p].Paragraphs
+		}
+		for _, v := range duplicateContentViolations(paths, prints) {
+			res.HasViolation = true
+			res.Events = append(res.Events, violationEvent(v))
+			if _, ok := ruleFiles[v.RuleID]; !ok {
+				ruleFiles[v.RuleID] = map[string]struct{}{}
+			}
+			for _, loc := range v.Locations {
+				ruleFiles[v.RuleID][loc.Path] = struct{}{}
+			}
+		}
+	}
+
+	if opts.Mode == ModeCheck && hasDirectoryRules(cfg.RuleSet.Rules) {
+		for _, v := range evaluateDirectoryRules(cfg.RuleSet.Rules, opts.CWD, cfg.Roots, scanRes.Dirs) {
+			res.HasViolation = true
+			res.Events = append(res.Events, violationEvent(v))
+			if _, ok := ruleFiles[v.RuleID]; !ok {
+				ruleFiles[v.RuleID] = map[string]struct{}{}
+			}
+			ruleFiles[v.RuleID][v.Path] = struct{}{}
+		}
+	}
+
+	if opts.Mode == ModeCheck && hasCorpusRules(cfg.RuleSet.Rules) {
+		files := make([]corpusFile, 0, len(paths))
+		for _, p := range paths {
+			files = append(files, corpusFile{Path: p, RelPath: relPath(opts.CWD, p), Chars: byPath[p].Chars})
+		}
+		for _, v := range evaluateCorpusRules(cfg.RuleSet.Rules, files) {
+			res.HasViolation = true
+			res.Events = append(res.Events, violationEvent(v))
+			if _, ok := ruleFiles[v.RuleID]; !ok {
+				ruleFiles[v.RuleID] = map[string]struct{}{}
+			}
+			for _, f := range v.TopFiles {
+				ruleFiles[v.RuleID][f.Path] = struct{}{}
+			}
+		}
+	}
+
 	res.Summary.RuleStats = map[string]RuleStats{}
 	for _, e := range res.Events {
 		t, _ := e["type"].(string)
@@ -169,6 +279,10 @@ func Run(opts Options) (Result, error) {
 			res.Summary.PassCount++
 		case "error":
 			res.Summary.Errors++
+		case "warning":
+			res.Summary.Warnings++
+		case "redaction":
+			res.Summary.Redactions++
 		}
 	}
 	for rid, files := range ruleFiles {
@@ -177,10 +291,28 @@ func Run(opts Options) (Result, error) {
 		res.Summary.RuleStats[rid] = rs
 	}
 
-	res.Events = append(res.Events, buildSummary(opts.Mode, res.Summary, decideExitCode(res)))
+	res.Events = append(res.Events, buildSummary(opts, res.Summary, decideExitCode(res)))
 	return res, nil
 }
 
+// skipFile 记录跳过的文件。redact 模式下跳过的文件没有脱敏副本，按输入错误处理，
+// 并补一条 status 为 skipped 的 redacted_file 事件，避免未脱敏的文件被当成已处理。
+func skipFile(fr fileResult, opts Options, code, detail string) fileResult {
+	fr.Skipped = true
+	fr.Events = append(fr.Events, buildErrorEvent("input", code, fr.Path, detail))
+	if opts.Mode == ModeRedact {
+		fr.HasInputErr = true
+		fr.Events = append(fr.Events, map[string]any{
+			"type":       "redacted_file",
+			"path":       fr.Path,
+			"status":     "skipped",
+			"output":     "",
+			"redactions": 0,
+		})
+	}
+	return fr
+}
+
 func processFile(path string, opts Options, cfg RuntimeConfig) fileResult {
 	fr := fileResult{Path: path, Events: make([]map[string]any, 0), RuleHit: map[string]struct{}{}}
 	info, err := os.Stat(path)
@@ -193,10 +325,18 @@ func processFile(path string, opts Options, cfg RuntimeConfig) fileResult {
 		return fr
 	}
 
+	pathViolations := 0
+	if opts.Mode == ModeCheck && hasPathRules(cfg.RuleSet.Rules) {
+		for _, v := range cfg.RuleSet.EvaluatePath(path, inputRoot(cfg.Roots, path)) {
+			pathViolations++
+			fr.HasViolation = true
+			fr.RuleHit[v.RuleID] = struct{}{}
+			fr.Events = append(fr.Events, violationEvent(v))
+		}
+	}
+
 	if info.Size() > opts.MaxFileSizeBytes {
-		fr.Skipped = true
-		fr.Events = append(fr.Events, buildErrorEvent("input", "skipped_large_file", path, fmt.Sprintf("文件大小 %d 超过上限 %d", info.Size(), opts.MaxFileSizeBytes)))
-		return fr
+		return skipFile(fr, opts, "skipped_large_file", fmt.Sprintf("文件大小 %d 超过上限 %d", info.Size(), opts.MaxFileSizeBytes))
 	}
 
 	data, err := os.ReadFile(path)
@@ -211,46 +351,113 @@ func processFile(path string, opts Options, cfg RuntimeConfig) fileResult {
 		sample = sample[:8192]
 	}
 	if textutil.DetectBinary(sample) {
-		fr.Skipped = true
-		fr.Events = append(fr.Events, buildErrorEvent("input", "skipped_binary_file", path, "识别为二进制文件，已跳过"))
-		return fr
+		return skipFile(fr, opts, "skipped_binary_file", "识别为二进制文件，已跳过")
 	}
 
 	decoded, err := textutil.Decode(data)
 	if err != nil {
-		fr.Skipped = true
-		fr.Events = append(fr.Events, buildErrorEvent("input", "decode_failed", path, err.Error()))
-		return fr
+		return skipFile(fr, opts, "decode_failed", err.Error())
 	}
 	metrics := textutil.ComputeMetrics(decoded.Text)
 
+	if opts.Mode == ModeRedact {
+		return redactFile(fr, decoded, metrics, opts, cfg)
+	}
+
 	if opts.Mode == ModeStats {
+		zh := textutil.DetectChineseVariant(decoded.Text)
+		seg := textutil.ComputeSegmentStats(metrics.LinesText)
 		ev := map[string]any{
-			"type":           "file_stats",
-			"path":           path,
-			"status":         "ok",
-			"encoding":       decoded.Encoding,
-			"file_size":      len(data),
-			"hash":           textutil.HashSHA256(data),
-			"line_ending":    metrics.LineEnding,
-			"language_guess": metrics.Language,
-			"chars":          metrics.Chars,
-			"lines":          metrics.Lines,
-			"max_line_width": metrics.MaxLineWidth,
+			"type":                "file_stats",
+			"path":                path,
+			"status":              "ok",
+			"encoding":            decoded.Encoding,
+			"file_size":           len(data),
+			"hash":                textutil.HashSHA256(data),
+			"line_ending":         metrics.LineEnding,
+			"language_guess":      metrics.Language,
+			"languages":           textutil.DetectLanguages(decoded.Text, 3),
+			"chars":               metrics.Chars,
+			"lines":               metrics.Lines,
+			"max_line_width":      metrics.MaxLineWidth,
+			"sentences":           seg.Sentences,
+			"paragraphs":          seg.Paragraphs,
+			"avg_sentence_length": seg.AvgSentenceLength,
+			"max_sentence_length": seg.MaxSentenceLength,
+			"zh_variant":          zh.Variant,
+			"zh_hans_chars":       zh.HansChars,
+			"zh_hant_chars":       zh.HantChars,
+			"zh_mixed_ratio":      zh.MixedRatio,
+		}
+		if rd, ok := textutil.ComputeReadability(metrics.LinesText, metrics.Language); ok {
+			ev["readability"] = rd
+		}
+		var sections []docSection
+		if splitter := cfg.RuleSet.splitterFor(path); splitter != nil {
+			sections = collectSections(metrics.LinesText, splitter)
+		}
+		if opts.Sections {
+			ev["sections"] = sectionsDebug(sections)
+		}
+		if secs := sectionReadability(sections); len(secs) > 0 {
+			ev["section_readability"] = secs
+		}
+		speed := cfg.RuleSet.speed
+		rt := textutil.EstimateReadingTime(decoded.Text, speed)
+		ev["reading_time_seconds"] = rt.ReadingSeconds
+		ev["speaking_time_seconds"] = rt.SpeakingSeconds
+		if secs := sectionReadingTime(sections, speed); len(secs) > 0 {
+			ev["section_reading_time"] = secs
+		}
+		if opts.DetectSimilar {
+			sig, ok := textutil.ComputeMinHash(decoded.Text)
+			fr.Similar = &similarInput{Path: path, Hash: ev["hash"].(string), Signature: sig, HasSig: ok}
 		}
 		fr.Events = append(fr.Events, ev)
 		fr.Processed = true
 		return fr
 	}
 
-	fc := FileContent{Path: path, Data: data, Text: decoded.Text, Encoding: decoded.Encoding, Metrics: metrics}
-	violations, verrs := EvaluateRules(fc, cfg.Rules)
-	for _, e := range verrs {
-		fr.HasInputErr = true
-		fr.Events = append(fr.Events, buildErrorEvent("config", "rule_eval_error", path, e.Error()))
+	fc := FileContent{Path: path, RelPath: relPath(opts.CWD, path), Data: data, Text: decoded.Text, Encoding: decoded.Encoding, Metrics: metrics}
+	fr.Chars = metrics.Chars
+	result := cfg.RuleSet.Evaluate(fc)
+	violations := result.Violations
+	fr.Suppressed = result.Suppressed
+	fr.Paragraphs = result.paragraphs
+	warnings := result.Warnings
+	if opts.ReportUnusedDisables {
+		warnings = append(warnings, result.UnusedDisables...)
+	}
+	for _, w := range warnings {
+		ev := map[string]any{
+			"type":   "warning",
+			"code":   w.Code,
+			"path":   w.Path,
+			"detail": w.Detail,
+		}
+		if w.Line > 0 {
+			ev["line"] = w.Line
+		}
+		fr.Events = append(fr.Events, ev)
 	}
 
-	if len(violations) == 0 && len(verrs) == 0 {
+	if opts.Fix && len(violations) > 0 {
+		remaining, fixed, ferr := applyFixes(fc, violations)
+		if ferr != nil {
+			fr.HasInputErr = true
+			fr.Events = append(fr.Events, buildErrorEvent("input", "fix_write_failed", path, ferr.Error()))
+		} else if fixed > 0 {
+			fr.Fixed = fixed
+			fr.Events = append(fr.Events, map[string]any{
+				"type":  "fix",
+				"path":  path,
+				"fixed": fixed,
+			})
+		}
+		violations = remaining
+	}
+
+	if len(violations) == 0 && pathViolations == 0 {
 		fr.Events = append(fr.Events, map[string]any{
 			"type": "pass",
 			"path": path,
@@ -262,30 +469,68 @@ func processFile(path string, opts Options, cfg RuntimeConfig) fileResult {
 		fr.HasViolation = true
 		for _, v := range violations {
 			fr.RuleHit[v.RuleID] = struct{}{}
-			fr.Events = append(fr.Events, map[string]any{
-				"type":                  "violation",
-				"rule_id":               v.RuleID,
-				"message":               v.Message,
-				"path":                  v.Path,
-				"line":                  v.Line,
-				"column":                v.Column,
-				"overflow_start_column": v.OverflowStartColumn,
-				"line_end_column":       v.LineEndColumn,
-				"snippet":               v.Snippet,
-				"actual":                v.Actual,
-				"limit":                 v.Limit,
-				"scope":                 v.Scope,
-			})
+			fr.Events = append(fr.Events, violationEvent(v))
 		}
 	}
 	fr.Processed = true
 	return fr
 }
 
-func buildSummary(mode Mode, s Summary, exitCode int) map[string]any {
+// compileRuntimeRules 在扫描前编译规则，配置错误只报一次（退出码 4）。
+// 统计模式只用到章节语法与阅读速度，其余规则的配置错误不影响统计。
+func compileRuntimeRules(mode Mode, rules config.Rules) (*RuleSet, error) {
+	if mode == ModeCheck || mode == ModeRedact {
+		rs, errs := CompileRules(rules)
+		if len(errs) > 0 {
+			return nil, &ConfigErr{Msg: joinConfigErrors(errs)}
+		}
+		return rs, nil
+	}
+	splitters, err := newSplitterSet(rules)
+	if err != nil {
+		return nil, &ConfigErr{Msg: err.Error()}
+	}
+	return &RuleSet{Rules: rules, speed: readingSpeedFromConfig(rules.ReadingSpeed), splitters: splitters}, nil
+}
+
+func violationEvent(v Violation) map[string]any {
+	ev := map[string]any{
+		"type":                  "violation",
+		"rule_id":               v.RuleID,
+		"message":               v.Message,
+		"path":                  v.Path,
+		"line":                  v.Line,
+		"column":                v.Column,
+		"overflow_start_column": v.OverflowStartColumn,
+		"line_end_column":       v.LineEndColumn,
+		"snippet":               v.Snippet,
+		"actual":                v.Actual,
+		"limit":                 v.Limit,
+		"scope":                 v.Scope,
+	}
+	if v.EndLine > 0 {
+		ev["end_line"] = v.EndLine
+		ev["end_column"] = v.EndColumn
+	}
+	if v.Suggestion != "" {
+		ev["suggestion"] = v.Suggestion
+	}
+	if v.Fix != nil {
+		ev["fix"] = v.Fix
+	}
+	if len(v.Locations) > 0 {
+		ev["locations"] = v.Locations
+	}
+	if len(v.TopFiles) > 0 {
+		ev["top_files"] = v.TopFiles
+	}
+	return ev
+}
+
+func buildSummary(opts Options, s Summary, exitCode int) map[string]any {
 	m := map[string]any{
 		"type":            "summary",
-		"mode":            string(mode),
+		"mode":            string(opts.Mode),
 		"total_files":     s.TotalFiles,
 		"processed_files": s.Processed,
 		"skipped_files":   s.Skipped,
@@ -294,6 +539,21 @@ func buildSummary(mode Mode, s Summary, exitCode int) map[string]any {
 		"error_count":     s.Errors,
 		"exit_code":       exitCode,
 	}
+	if opts.Fix {
+		m["fixed_count"] = s.Fixed
+	}
+	if s.Warnings > 0 {
+		m["warning_count"] = s.Warnings
+	}
+	if s.Suppressed > 0 {
+		m["suppressed_count"] = s.Suppressed
+	}
+	if opts.DetectSimilar {
+		m["similar_group_count"] = s.Similar
+	}
+	if opts.Mode == ModeRedact {
+		m["redaction_count"] = s.Redactions
+	}
 	if len(s.RuleStats) > 0 {
 		m["rule_stats"] = s.RuleStats
 	}
@@ -347,3 +607,48 @@ func NormalizePaths(paths []string, cwd string) []string {
 	sort.Strings(out)
 	return out
 }
+
+// sectionReadability 为每个能评分的 Markdown 章节给出可读性，语言按章节单独识别。
+func sectionReadability(sections []docSection) []map[string]any {
+	out := make([]map[string]any, 0)
+	for _, sec := range sections {
+		rd, ok := textutil.ComputeReadability(sec.Metrics.LinesText, sec.Metrics.Language)
+		if !ok {
+			continue
+		}
+		out = append(out, map[string]any{
+			"heading":      sec.Heading,
+			"line":         sec.HeadingLine,
+			"language":     sec.Metrics.Language,
+			"method":       rd.Method,
+			"reading_ease": rd.ReadingEase,
+			"grade_level":  rd.GradeLevel,
+		})
+	}
+	return out
+}
+
+func sectionReadingTime(sections []docSection, speed textutil.ReadingSpeed) []map[string]any {
+	out := make([]map[string]any, 0)
+	for _, sec := range sections {
+		rt := textutil.EstimateReadingTime(sec.Text, speed)
+		out = append(out, map[string]any{
+			"heading":               sec.Heading,
+			"line":                  sec.HeadingLine,
+			"reading_time_seconds":  rt.ReadingSeconds,
+			"speaking_time_seconds": rt.SpeakingSeconds,
+		})
+	}
+	return out
+}
+
+func relPath(cwd, path string) string {
+	if cwd == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
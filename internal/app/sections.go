@@ -0,0 +1,416 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"syl-wordcount/internal/config"
+	"syl-wordcount/internal/textutil"
+)
+
+const (
+	SyntaxAuto     = "auto"
+	SyntaxMarkdown = "markdown"
+	SyntaxRst      = "rst"
+	SyntaxAdoc     = "adoc"
+	SyntaxOrg      = "org"
+	SyntaxCustom   = "custom"
+)
+
+// defaultCustomHeadingPattern 识别“1.2 标题”这类编号标题，级别取编号段数。
+const defaultCustomHeadingPattern = `^(?P<level>\d+(?:\.\d+)*)\.?[ \t]+(?P<title>\S.*?)\s*$`
+
+// sectionSplitter 从文本行中找出标题，章节范围由 collectSections 统一计算。
+type sectionSplitter interface {
+	Headings(lines []string) []headingPos
+}
+
+var syntaxByExt = map[string]string{
+	".md":       SyntaxMarkdown,
+	".markdown": SyntaxMarkdown,
+	".mdx":      SyntaxMarkdown,
+	".mkd":      SyntaxMarkdown,
+	".rst":      SyntaxRst,
+	".rest":     SyntaxRst,
+	".adoc":     SyntaxAdoc,
+	".asciidoc": SyntaxAdoc,
+	".asc":      SyntaxAdoc,
+	".org":      SyntaxOrg,
+}
+
+// splitterSet 是按 section_syntax 预先构建好的切分器：byExt 为扩展名映射，def 为统一设置，
+// def 为 nil 表示按扩展名自动识别。custom 语法的正则只编译一次。
+type splitterSet struct {
+	byExt map[string]sectionSplitter
+	def   sectionSplitter
+	rules config.Rules
+}
+
+// newSplitterSet 校验 section_syntax 并构建切分器。
+func newSplitterSet(rules config.Rules) (*splitterSet, error) {
+	for e, s := range rules.SectionSyntax.ByExt {
+		if !isKnownSyntax(normalizeSyntax(s)) {
+			return nil, fmt.Errorf("section_syntax 配置错误：扩展名 %s 的章节语法未知：%s（可选 auto/markdown/rst/adoc/org/custom）", e, s)
+		}
+	}
+	def := normalizeSyntax(rules.SectionSyntax.Default)
+	if !isKnownSyntax(def) {
+		return nil, fmt.Errorf("section_syntax 配置错误：未知章节语法：%s（可选 auto/markdown/rst/adoc/org/custom）", rules.SectionSyntax.Default)
+	}
+
+	set := &splitterSet{byExt: map[string]sectionSplitter{}, rules: rules}
+	var custom sectionSplitter
+	build := func(syntax string) (sectionSplitter, error) {
+		if syntax != SyntaxCustom {
+			return set.builtin(syntax), nil
+		}
+		if custom == nil {
+			sp, err := newRegexSplitter(rules.SectionHeadingPattern)
+			if err != nil {
+				return nil, err
+			}
+			custom = sp
+		}
+		return custom, nil
+	}
+	for e, s := range rules.SectionSyntax.ByExt {
+		syntax := normalizeSyntax(s)
+		if syntax == SyntaxAuto {
+			syntax = autoSyntax(e)
+		}
+		sp, err := build(syntax)
+		if err != nil {
+			return nil, err
+		}
+		set.byExt[e] = sp
+	}
+	if def != SyntaxAuto {
+		sp, err := build(def)
+		if err != nil {
+			return nil, err
+		}
+		set.def = sp
+	}
+	return set, nil
+}
+
+// For 为文件选择切分器：先看扩展名映射，再看统一设置，都是 auto 时按扩展名识别，未知扩展名按 Markdown 处理。
+func (s *splitterSet) For(path string) sectionSplitter {
+	ext := strings.ToLower(filepath.Ext(path))
+	if sp, ok := s.byExt[ext]; ok {
+		return sp
+	}
+	if s.def != nil {
+		return s.def
+	}
+	return s.builtin(autoSyntax(ext))
+}
+
+// Document 判断文件是否有明确的文档语法：扩展名可识别、在 section_syntax.by_ext 中配置，
+// 或设置了统一的 section_syntax.default。未知扩展名只是回退到 Markdown 切分器，不算文档。
+func (s *splitterSet) Document(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	if _, ok := s.byExt[ext]; ok {
+		return true
+	}
+	return s.def != nil || syntaxByExt[ext] != ""
+}
+
+func (s *splitterSet) builtin(syntax string) sectionSplitter {
+	switch syntax {
+	case SyntaxRst:
+		return rstSplitter{}
+	case SyntaxAdoc:
+		return adocSplitter{}
+	case SyntaxOrg:
+		return orgSplitter{}
+	default:
+		return markdownSplitter{HTMLHeadings: s.rules.MarkdownHTMLHeadings}
+	}
+}
+
+func autoSyntax(ext string) string {
+	if syntax := syntaxByExt[ext]; syntax != "" {
+		return syntax
+	}
+	return SyntaxMarkdown
+}
+
+func normalizeSyntax(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", SyntaxAuto:
+		return SyntaxAuto
+	case "md", SyntaxMarkdown:
+		return SyntaxMarkdown
+	case "rest", "restructuredtext", SyntaxRst:
+		return SyntaxRst
+	case "asciidoc", SyntaxAdoc:
+		return SyntaxAdoc
+	case SyntaxOrg:
+		return SyntaxOrg
+	case SyntaxCustom:
+		return SyntaxCustom
+	}
+	return s
+}
+
+func isKnownSyntax(s string) bool {
+	switch s {
+	case SyntaxAuto, SyntaxMarkdown, SyntaxRst, SyntaxAdoc, SyntaxOrg, SyntaxCustom:
+		return true
+	}
+	return false
+}
+
+// collectSections 把标题切分为章节：章节到下一个同级或更高级标题之前结束，包含其下的子章节。
+func collectSections(lines []string, splitter sectionSplitter) []docSection {
+	headings := splitter.Headings(lines)
+	if len(headings) == 0 {
+		return nil
+	}
+
+	sections := make([]docSection, 0, len(headings))
+	for i, h := range headings {
+		end := len(lines) - 1
+		for j := i + 1; j < len(headings); j++ {
+			if headings[j].Level <= h.Level {
+				end = headings[j].Start - 1
+				break
+			}
+		}
+		content := ""
+		if h.BodyStart <= end {
+			content = strings.Join(lines[h.BodyStart:end+1], "\n")
+		}
+		sections = append(sections, docSection{
+			Heading:     h.Title,
+			HeadingLine: h.Line + 1,
+			Level:       h.Level,
+			Syntax:      h.Syntax,
+			StartLine:   h.BodyStart + 1,
+			EndLine:     end + 1,
+			Text:        content,
+			Metrics:     textutil.ComputeMetrics(content),
+		})
+	}
+	return sections
+}
+
+// rstSplitter 识别 reStructuredText 标题：文本行加下划线，或上下划线包围的文本行。
+// 级别按装饰样式（字符 + 是否有上划线）首次出现的顺序确定。
+type rstSplitter struct{}
+
+func (rstSplitter) Headings(lines []string) []headingPos {
+	headings := make([]headingPos, 0)
+	styles := make([]string, 0)
+	levelOf := func(style string) int {
+		for i, s := range styles {
+			if s == style {
+				return i + 1
+			}
+		}
+		styles = append(styles, style)
+		return len(styles)
+	}
+	prevBlank := true
+	for i := 0; i < len(lines); i++ {
+		ln := lines[i]
+		if ch, n := rstAdornment(ln); n > 0 && prevBlank && i+2 < len(lines) {
+			title := strings.TrimSpace(lines[i+1])
+			ch2, n2 := rstAdornment(lines[i+2])
+			if title != "" && ch2 == ch && n2 == n && n >= textutil.DisplayWidth(title) {
+				headings = append(headings, headingPos{Title: title, Start: i, Line: i + 1, Level: levelOf("o" + string(ch)), BodyStart: i + 3, Syntax: SyntaxRst})
+				i += 2
+				prevBlank = false
+				continue
+			}
+		}
+		if prevBlank && i+1 < len(lines) && strings.TrimSpace(ln) != "" && leadingIndent(ln) == 0 {
+			if _, n := rstAdornment(ln); n == 0 {
+				title := strings.TrimSpace(ln)
+				if ch, n := rstAdornment(lines[i+1]); n > 0 && (n >= textutil.DisplayWidth(title) || n >= 4) {
+					headings = append(headings, headingPos{Title: title, Start: i, Line: i, Level: levelOf("u" + string(ch)), BodyStart: i + 2, Syntax: SyntaxRst})
+					i++
+					prevBlank = false
+					continue
+				}
+			}
+		}
+		prevBlank = strings.TrimSpace(ln) == ""
+	}
+	return headings
+}
+
+// rstAdornment 判断一行是否为 rst 装饰线（同一个标点字符重复至少 2 次），返回字符与长度。
+func rstAdornment(ln string) (rune, int) {
+	s := strings.TrimRight(ln, " \t")
+	if utf8.RuneCountInString(s) < 2 {
+		return 0, 0
+	}
+	first, _ := utf8.DecodeRuneInString(s)
+	if first > unicode.MaxASCII || (!unicode.IsPunct(first) && !unicode.IsSymbol(first)) {
+		return 0, 0
+	}
+	for _, r := range s {
+		if r != first {
+			return 0, 0
+		}
+	}
+	return first, len(s)
+}
+
+var (
+	adocHeadingRegex   = regexp.MustCompile(`^(={1,6}|#{1,6})[ \t]+(\S.*?)[ \t]*$`)
+	adocDelimiterRegex = regexp.MustCompile("^(-{4,}|\\.{4,}|\\+{4,}|/{4,}|={4,}|\\*{4,}|_{4,}|`{3,})[ \t]*$")
+)
+
+// adocSplitter 识别 AsciiDoc 标题（= 文档标题、== 一级章节……），跳过分隔块与注释行。
+type adocSplitter struct{}
+
+func (adocSplitter) Headings(lines []string) []headingPos {
+	headings := make([]headingPos, 0)
+	delim := ""
+	for i, ln := range lines {
+		if delim != "" {
+			if strings.TrimRight(ln, " \t") == delim {
+				delim = ""
+			}
+			continue
+		}
+		if m := adocDelimiterRegex.FindStringSubmatch(ln); m != nil {
+			delim = m[1]
+			continue
+		}
+		if strings.HasPrefix(ln, "//") {
+			continue
+		}
+		m := adocHeadingRegex.FindStringSubmatch(ln)
+		if m == nil {
+			continue
+		}
+		title := strings.TrimSpace(strings.TrimRight(m[2], string(m[1][0])))
+		if title == "" {
+			continue
+		}
+		headings = append(headings, headingPos{Title: title, Start: i, Line: i, Level: len(m[1]), BodyStart: i + 1, Syntax: SyntaxAdoc})
+	}
+	return headings
+}
+
+var (
+	orgHeadingRegex  = regexp.MustCompile(`^(\*+)[ \t]+(.*?)[ \t]*$`)
+	orgTodoRegex     = regexp.MustCompile(`^(?:TODO|DONE)(?:[ \t]+|$)`)
+	orgPriorityRegex = regexp.MustCompile(`^\[#[A-Za-z0-9]\][ \t]*`)
+	orgTagsRegex     = regexp.MustCompile(`[ \t]+:(?:[\w@#%]+:)+$`)
+	orgBlockRegex    = regexp.MustCompile(`(?i)^[ \t]*#\+begin_(\S+)`)
+)
+
+// orgSplitter 识别 Org-mode 标题（* / ** ……），标题去掉 TODO/DONE、优先级与尾部标签，跳过 #+BEGIN_ 块。
+type orgSplitter struct{}
+
+func (orgSplitter) Headings(lines []string) []headingPos {
+	headings := make([]headingPos, 0)
+	blockEnd := ""
+	for i, ln := range lines {
+		if blockEnd != "" {
+			if strings.EqualFold(strings.TrimSpace(ln), blockEnd) {
+				blockEnd = ""
+			}
+			continue
+		}
+		if m := orgBlockRegex.FindStringSubmatch(ln); m != nil {
+			blockEnd = "#+end_" + m[1]
+			continue
+		}
+		m := orgHeadingRegex.FindStringSubmatch(ln)
+		if m == nil {
+			continue
+		}
+		title := orgTodoRegex.ReplaceAllString(m[2], "")
+		title = orgPriorityRegex.ReplaceAllString(title, "")
+		title = strings.TrimSpace(orgTagsRegex.ReplaceAllString(title, ""))
+		if title == "" {
+			continue
+		}
+		headings = append(headings, headingPos{Title: title, Start: i, Line: i, Level: len(m[1]), BodyStart: i + 1, Syntax: SyntaxOrg})
+	}
+	return headings
+}
+
+// regexSplitter 用正则逐行识别标题：命名分组 title 为标题文本（缺省取整个匹配）；
+// 命名分组 level 含数字时按“.”分隔的段数定级（1.2 → 2），否则按字符数定级（** → 2），缺省为 1。
+type regexSplitter struct {
+	rx       *regexp.Regexp
+	titleIdx int
+	levelIdx int
+}
+
+func newRegexSplitter(pattern string) (sectionSplitter, error) {
+	if strings.TrimSpace(pattern) == "" {
+		pattern = defaultCustomHeadingPattern
+	}
+	rx, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("section_heading_pattern 编译失败：%w", err)
+	}
+	return regexSplitter{rx: rx, titleIdx: rx.SubexpIndex("title"), levelIdx: rx.SubexpIndex("level")}, nil
+}
+
+func (s regexSplitter) Headings(lines []string) []headingPos {
+	headings := make([]headingPos, 0)
+	for i, ln := range lines {
+		m := s.rx.FindStringSubmatch(ln)
+		if m == nil {
+			continue
+		}
+		title := m[0]
+		if s.titleIdx > 0 {
+			title = m[s.titleIdx]
+		}
+		title = strings.TrimSpace(title)
+		if title == "" {
+			continue
+		}
+		level := 1
+		if s.levelIdx > 0 {
+			level = customHeadingLevel(m[s.levelIdx])
+		}
+		headings = append(headings, headingPos{Title: title, Start: i, Line: i, Level: level, BodyStart: i + 1, Syntax: SyntaxCustom})
+	}
+	return headings
+}
+
+func customHeadingLevel(marker string) int {
+	marker = strings.TrimSpace(marker)
+	if strings.ContainsAny(marker, "0123456789") {
+		n := 0
+		for _, part := range strings.Split(marker, ".") {
+			if part != "" {
+				n++
+			}
+		}
+		return max(n, 1)
+	}
+	return max(utf8.RuneCountInString(marker), 1)
+}
+
+// sectionsDebug 列出识别到的章节及其范围，供 stats --sections 排查章节切分。
+func sectionsDebug(sections []docSection) []map[string]any {
+	out := make([]map[string]any, 0, len(sections))
+	for _, sec := range sections {
+		out = append(out, map[string]any{
+			"heading":      sec.Heading,
+			"level":        sec.Level,
+			"syntax":       sec.Syntax,
+			"heading_line": sec.HeadingLine,
+			"start_line":   sec.StartLine,
+			"end_line":     sec.EndLine,
+			"chars":        sec.Metrics.Chars,
+		})
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"math"
+	"sort"
+
+	"syl-wordcount/internal/textutil"
+)
+
+const (
+	// DefaultSimilarityThreshold 是 --detect-similar 的默认相似度阈值。
+	DefaultSimilarityThreshold = 0.8
+	// similarBands 把 128 位签名分成 32 段、每段 4 位，相似度约 0.42 以上的两份文件大概率落进同一个桶。
+	similarBands = 32
+)
+
+// similarInput 是一个文件参与近似重复检测所需的信息。
+type similarInput struct {
+	Path      string
+	Hash      string
+	Signature textutil.MinHash
+	HasSig    bool
+}
+
+type similarPair struct {
+	A     string  `json:"a"`
+	B     string  `json:"b"`
+	Score float64 `json:"score"`
+}
+
+// similarGroupEvents 先按 SHA256 输出完全相同的文件组（kind 为 exact），再用 MinHash + LSH 找出相似度
+// 不低于 threshold 的文件对，按连通关系合并成近似重复组（kind 为 near），列出组内两两之间的估算相似度。
+// 内容完全相同的文件之间不算近似重复的连边；inputs 须已按路径排序。
+func similarGroupEvents(inputs []similarInput, threshold float64) []map[string]any {
+	events := make([]map[string]any, 0)
+
+	byHash := map[string][]string{}
+	hashOrder := make([]string, 0)
+	for _, in := range inputs {
+		if _, ok := byHash[in.Hash]; !ok {
+			hashOrder = append(hashOrder, in.Hash)
+		}
+		byHash[in.Hash] = append(byHash[in.Hash], in.Path)
+	}
+	for _, h := range hashOrder {
+		if files := byHash[h]; len(files) > 1 {
+			events = append(events, map[string]any{
+				"type":  "similar_group",
+				"kind":  "exact",
+				"hash":  h,
+				"files": files,
+			})
+		}
+	}
+
+	parent := make([]int, len(inputs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	buckets := map[[2]uint64][]int{}
+	seen := map[[2]int]struct{}{}
+	for i := range inputs {
+		if !inputs[i].HasSig {
+			continue
+		}
+		for b, h := range inputs[i].Signature.Bands(similarBands) {
+			key := [2]uint64{uint64(b), h}
+			for _, j := range buckets[key] {
+				pair := [2]int{j, i}
+				if _, ok := seen[pair]; ok {
+					continue
+				}
+				seen[pair] = struct{}{}
+				if inputs[i].Hash == inputs[j].Hash {
+					continue
+				}
+				if inputs[i].Signature.Similarity(&inputs[j].Signature) >= threshold {
+					parent[find(i)] = find(j)
+				}
+			}
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	members := map[int][]int{}
+	rootOrder := make([]int, 0)
+	for i := range inputs {
+		if !inputs[i].HasSig {
+			continue
+		}
+		r := find(i)
+		if _, ok := members[r]; !ok {
+			rootOrder = append(rootOrder, r)
+		}
+		members[r] = append(members[r], i)
+	}
+	for _, r := range rootOrder {
+		idx := members[r]
+		if len(idx) < 2 {
+			continue
+		}
+		sort.Ints(idx)
+		files := make([]string, 0, len(idx))
+		pairs := make([]similarPair, 0)
+		for a, i := range idx {
+			files = append(files, inputs[i].Path)
+			for _, j := range idx[a+1:] {
+				score := inputs[i].Signature.Similarity(&inputs[j].Signature)
+				pairs = append(pairs, similarPair{A: inputs[i].Path, B: inputs[j].Path, Score: math.Round(score*1000) / 1000})
+			}
+		}
+		events = append(events, map[string]any{
+			"type":  "similar_group",
+			"kind":  "near",
+			"files": files,
+			"pairs": pairs,
+		})
+	}
+	return events
+}
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+
+	"syl-wordcount/internal/textutil"
+)
+
+// 行内指令可写在 HTML/Markdown 注释里，也可直接写在纯文本中：
+//
+//	<!-- syl-wc-disable-next-line max_line_width -->
+//	<!-- syl-wc-disable no_tabs, max_line_width -->  …  <!-- syl-wc-enable -->
+//	# syl-wc-disable-file
+//
+// 指令后的规则列表用逗号或空白分隔，省略表示所有规则；写 zh_typography 这样的前缀可覆盖
+// zh_typography.* 一组规则。“--” 之后是说明文字，不参与解析。指令名后必须是空白、注释结尾或行尾，
+// syl-wc-disable-foo 这类写法不算指令。
+var directiveRe = regexp.MustCompile(`syl-wc-(disable-next-line|disable-line|disable-file|disable|enable)(?:\s|-->|\*/|$)`)
+
+const (
+	directiveDisableNextLine = "disable-next-line"
+	directiveDisableLine     = "disable-line"
+	directiveDisableFile     = "disable-file"
+	directiveDisable         = "disable"
+	directiveEnable          = "enable"
+)
+
+// disableDirective 是一条抑制指令及其生效范围 [FromLine, ToLine]；文件级指令 File 为 true。
+type disableDirective struct {
+	Kind     string
+	Line     int
+	Text     string
+	Rules    []string // 为空表示所有规则
+	FromLine int
+	ToLine   int
+	File     bool
+	used     int
+}
+
+func (d *disableDirective) covers(v Violation) bool {
+	if !d.File && (v.Line == 0 || v.Line < d.FromLine || v.Line > d.ToLine) {
+		return false
+	}
+	return d.matchesRule(v.RuleID)
+}
+
+func (d *disableDirective) matchesRule(id string) bool {
+	if len(d.Rules) == 0 {
+		return true
+	}
+	for _, r := range d.Rules {
+		if id == r || strings.HasPrefix(id, r+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDirectives 从各行中找出抑制指令并确定生效范围。Markdown 文档跳过代码块与行内代码，
+// 写在代码里的示例指令不会生效。没有配对 enable 的 disable 一直生效到文件末尾。
+func parseDirectives(lines []string, markdown bool) []*disableDirective {
+	if markdown {
+		lines = textutil.MaskMarkdownCode(lines)
+	}
+	out := make([]*disableDirective, 0)
+	open := make([]*disableDirective, 0)
+	for i, ln := range lines {
+		if !strings.Contains(ln, "syl-wc-") {
+			continue
+		}
+		line := i + 1
+		locs := directiveRe.FindAllStringSubmatchIndex(ln, -1)
+		for k, loc := range locs {
+			end := len(ln)
+			if k+1 < len(locs) {
+				end = locs[k+1][0]
+			}
+			kind := ln[loc[2]:loc[3]]
+			rules := directiveRules(ln[loc[3]:end])
+			text := strings.TrimSpace("syl-wc-" + kind + " " + strings.Join(rules, ", "))
+			switch kind {
+			case directiveEnable:
+				open = closeDirectives(open, rules, line-1)
+			case directiveDisableNextLine:
+				out = append(out, &disableDirective{Kind: kind, Line: line, Text: text, Rules: rules, FromLine: line + 1, ToLine: line + 1})
+			case directiveDisableLine:
+				out = append(out, &disableDirective{Kind: kind, Line: line, Text: text, Rules: rules, FromLine: line, ToLine: line})
+			case directiveDisableFile:
+				out = append(out, &disableDirective{Kind: kind, Line: line, Text: text, Rules: rules, File: true})
+			case directiveDisable:
+				d := &disableDirective{Kind: kind, Line: line, Text: text, Rules: rules, FromLine: line, ToLine: len(lines)}
+				out = append(out, d)
+				open = append(open, d)
+			}
+		}
+	}
+	return out
+}
+
+// directiveRules 解析指令后的规则列表：截掉注释结尾与 “--” 说明，按逗号或空白分隔。
+func directiveRules(s string) []string {
+	for _, stop := range []string{"-->", "*/", " --", "\t--"} {
+		if i := strings.Index(s, stop); i >= 0 {
+			s = s[:i]
+		}
+	}
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == '，' || r == ' ' || r == '\t'
+	})
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// closeDirectives 用 enable 结束 disable 区间：不写规则时结束全部区间；写了规则时只结束
+// 规则列表里含这些规则的区间，不带规则的 disable 只能由不带规则的 enable 结束。
+func closeDirectives(open []*disableDirective, rules []string, toLine int) []*disableDirective {
+	kept := open[:0]
+	for _, d := range open {
+		if len(rules) == 0 || (len(d.Rules) > 0 && sharesRule(d.Rules, rules)) {
+			d.ToLine = toLine
+			continue
+		}
+		kept = append(kept, d)
+	}
+	return kept
+}
+
+func sharesRule(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyDirectives 去掉被指令抑制的违规，返回保留的违规、被抑制的条数与没有抑制任何违规的指令。
+// 行号为 0 的文件级违规只受 syl-wc-disable-file 影响；章节级违规按标题所在行判断。
+func applyDirectives(violations []Violation, directives []*disableDirective) ([]Violation, int, []*disableDirective) {
+	if len(directives) == 0 {
+		return violations, 0, nil
+	}
+	kept := make([]Violation, 0, len(violations))
+	suppressed := 0
+	for _, v := range violations {
+		hit := false
+		for _, d := range directives {
+			if d.covers(v) {
+				d.used++
+				hit = true
+			}
+		}
+		if hit {
+			suppressed++
+			continue
+		}
+		kept = append(kept, v)
+	}
+	unused := make([]*disableDirective, 0)
+	for _, d := range directives {
+		if d.used == 0 {
+			unused = append(unused, d)
+		}
+	}
+	return kept, suppressed, unused
+}
+
+// filterParagraphPrints 去掉被指令排除在 duplicate_content 比较之外的段落（按段落首行判断）。
+// 排除段落不计入 suppressed_count，但指令算作已生效。
+func filterParagraphPrints(prints []paragraphPrint, directives []*disableDirective) []paragraphPrint {
+	if len(directives) == 0 {
+		return prints
+	}
+	kept := prints[:0]
+	for _, pp := range prints {
+		hit := false
+		for _, d := range directives {
+			if d.covers(Violation{RuleID: "duplicate_content", Line: pp.Line}) {
+				d.used++
+				hit = true
+			}
+		}
+		if !hit {
+			kept = append(kept, pp)
+		}
+	}
+	return kept
+}
@@ -7,6 +7,8 @@ type Mode string
 const (
 	ModeStats Mode = "stats"
 	ModeCheck Mode = "check"
+	// ModeRedact 用 forbidden_patterns 与 secrets / pii 规则生成脱敏副本。
+	ModeRedact Mode = "redact"
 )
 
 type Options struct {
@@ -17,8 +19,19 @@ type Options struct {
 	Format           string
 	Jobs             int
 	MaxFileSizeBytes int64
-	Version          string
-	Args             []string
+	Fix              bool
+	Sections         bool
+	// DetectSimilar 为 true 时，统计模式额外输出完全相同与近似重复的文件组（similar_group）。
+	DetectSimilar       bool
+	SimilarityThreshold float64
+	// ReportUnusedDisables 为 true 时，没有抑制任何违规的行内指令输出 warning。
+	ReportUnusedDisables bool
+	// OutDir 是 redact 模式的输出目录，为空时只允许单个输入文件，脱敏内容放在 Result.Redacted。
+	OutDir string
+	// Mask 是 redact 模式的遮盖模板，{rule_id} 替换为规则 ID；为空时用 DefaultRedactMask。
+	Mask    string
+	Version string
+	Args    []string
 }
 
 type RuleStats struct {
@@ -33,6 +46,11 @@ type Summary struct {
 	PassCount  int                  `json:"pass_count"`
 	Violations int                  `json:"violation_count"`
 	Errors     int                  `json:"error_count"`
+	Fixed      int                  `json:"fixed_count,omitempty"`
+	Warnings   int                  `json:"warning_count,omitempty"`
+	Suppressed int                  `json:"suppressed_count,omitempty"`
+	Similar    int                  `json:"similar_group_count,omitempty"`
+	Redactions int                  `json:"redaction_count,omitempty"`
 	RuleStats  map[string]RuleStats `json:"rule_stats,omitempty"`
 }
 
@@ -43,8 +61,15 @@ type Result struct {
 	HasInputErr    bool
 	HasConfigErr   bool
 	HasInternalErr bool
+	// Redacted 是 redact 模式没有 --out-dir 时单个输入文件的脱敏内容（原编码），由调用方写到标准输出。
+	Redacted []byte
 }
 
+// RuntimeConfig 是一次运行共享的配置：RuleSet 在扫描前编译好，所有文件复用。
 type RuntimeConfig struct {
-	Rules config.Rules
+	Rules   config.Rules
+	RuleSet *RuleSet
+	Roots   []string // 规范化后的输入路径，路径规则据此计算层级
+	// OutPaths 是 redact 模式下每个输入文件的输出路径（设置了 --out-dir 时）。
+	OutPaths map[string]string
 }
@@ -3,55 +3,251 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// PatternRule 正则模式规则。Literal 为 true 时按字面匹配；WholeWord 要求命中两端是词边界；
+// MinCount/MaxCount 限制命中次数；Allow 中任一正则覆盖住的命中不计；Message/ID 替换默认的消息与规则 ID。
+// Multiline 让 ^/$ 匹配每行首尾，DotAll 让 . 匹配换行。
 type PatternRule struct {
-	Pattern       string `yaml:"pattern" json:"pattern"`
-	CaseSensitive *bool  `yaml:"case_sensitive" json:"case_sensitive"`
+	Pattern       string   `yaml:"pattern" json:"pattern"`
+	CaseSensitive *bool    `yaml:"case_sensitive" json:"case_sensitive"`
+	Literal       bool     `yaml:"literal" json:"literal"`
+	Multiline     bool     `yaml:"multiline" json:"multiline"`
+	DotAll        bool     `yaml:"dotall" json:"dotall"`
+	WholeWord     bool     `yaml:"whole_word" json:"whole_word"`
+	MinCount      *int     `yaml:"min_count" json:"min_count"`
+	MaxCount      *int     `yaml:"max_count" json:"max_count"`
+	Allow         []string `yaml:"allow" json:"allow"`
+	Message       string   `yaml:"message" json:"message"`
+	ID            string   `yaml:"id" json:"id"`
+}
+
+// ZhTypographyRules 中文排版规则组，每项可单独开关。
+type ZhTypographyRules struct {
+	CJKLatinSpace            bool `yaml:"cjk_latin_space" json:"cjk_latin_space"`
+	FullwidthPunctuation     bool `yaml:"fullwidth_punctuation" json:"fullwidth_punctuation"`
+	NoHalfwidthCommaAfterHan bool `yaml:"no_halfwidth_comma_after_han" json:"no_halfwidth_comma_after_han"`
+	NoDuplicatePunctuation   bool `yaml:"no_duplicate_punctuation" json:"no_duplicate_punctuation"`
+}
+
+func (z ZhTypographyRules) Any() bool {
+	return z.CJKLatinSpace || z.FullwidthPunctuation || z.NoHalfwidthCommaAfterHan || z.NoDuplicatePunctuation
+}
+
+// RequiredSections 必需章节。YAML 中可直接写标题列表，也可写成带 ordered/level/files 的对象。
+type RequiredSections struct {
+	Headings []string `yaml:"headings" json:"headings"`
+	Ordered  bool     `yaml:"ordered" json:"ordered"`
+	Level    int      `yaml:"level" json:"level"`
+	Files    []string `yaml:"files" json:"files"`
+}
+
+func (r *RequiredSections) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		return node.Decode(&r.Headings)
+	}
+	type plain RequiredSections
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*r = RequiredSections(p)
+	return nil
+}
+
+// SectionSyntax 章节切分语法。YAML 中写成字符串时对所有文件生效（如 rst），
+// 写成映射时按扩展名指定（如 {".txt": custom}），未列出的扩展名按扩展名自动识别。
+type SectionSyntax struct {
+	Default string
+	ByExt   map[string]string
+}
+
+func (s *SectionSyntax) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&s.Default)
+	}
+	var m map[string]string
+	if err := node.Decode(&m); err != nil {
+		return err
+	}
+	s.ByExt = map[string]string{}
+	for ext, syntax := range m {
+		s.ByExt[normalizeExt(ext)] = syntax
+	}
+	return nil
+}
+
+// ParseSectionSyntax 解析环境变量形式：单个语法名（rst），或逗号分隔的 扩展名=语法（.txt=custom,.rst=rst）。
+func ParseSectionSyntax(v string) (SectionSyntax, error) {
+	s := SectionSyntax{}
+	for _, part := range splitCSV(v) {
+		ext, syntax, ok := strings.Cut(part, "=")
+		if !ok {
+			if s.Default != "" {
+				return SectionSyntax{}, fmt.Errorf("只能设置一个默认章节语法：%s", v)
+			}
+			s.Default = part
+			continue
+		}
+		if s.ByExt == nil {
+			s.ByExt = map[string]string{}
+		}
+		s.ByExt[normalizeExt(ext)] = strings.TrimSpace(syntax)
+	}
+	return s, nil
+}
+
+func normalizeExt(ext string) string {
+	e := strings.ToLower(strings.TrimSpace(ext))
+	if e != "" && !strings.HasPrefix(e, ".") {
+		e = "." + e
+	}
+	return e
+}
+
+// ReadingSpeed 估算阅读/朗读时长的速度，未设置（0）时使用默认值。
+type ReadingSpeed struct {
+	WordsPerMinute         int `yaml:"words_per_minute" json:"words_per_minute"`
+	CharsPerMinute         int `yaml:"chars_per_minute" json:"chars_per_minute"`
+	SpeakingWordsPerMinute int `yaml:"speaking_words_per_minute" json:"speaking_words_per_minute"`
+	SpeakingCharsPerMinute int `yaml:"speaking_chars_per_minute" json:"speaking_chars_per_minute"`
 }
 
 type SectionScopedRules struct {
-	MinChars                 *int          `yaml:"min_chars" json:"min_chars"`
-	MaxChars                 *int          `yaml:"max_chars" json:"max_chars"`
-	MinLines                 *int          `yaml:"min_lines" json:"min_lines"`
-	MaxLines                 *int          `yaml:"max_lines" json:"max_lines"`
-	MaxLineWidth             *int          `yaml:"max_line_width" json:"max_line_width"`
-	AvgLineWidth             *int          `yaml:"avg_line_width" json:"avg_line_width"`
-	NoTrailingSpaces         bool          `yaml:"no_trailing_spaces" json:"no_trailing_spaces"`
-	NoTabs                   bool          `yaml:"no_tabs" json:"no_tabs"`
-	NoFullwidthSpace         bool          `yaml:"no_fullwidth_space" json:"no_fullwidth_space"`
-	MaxConsecutiveBlankLines *int          `yaml:"max_consecutive_blank_lines" json:"max_consecutive_blank_lines"`
-	ForbiddenPatterns        []PatternRule `yaml:"forbidden_patterns" json:"forbidden_patterns"`
-	RequiredPatterns         []PatternRule `yaml:"required_patterns" json:"required_patterns"`
+	MinChars                 *int              `yaml:"min_chars" json:"min_chars"`
+	MaxChars                 *int              `yaml:"max_chars" json:"max_chars"`
+	MinLines                 *int              `yaml:"min_lines" json:"min_lines"`
+	MaxLines                 *int              `yaml:"max_lines" json:"max_lines"`
+	MaxLineWidth             *int              `yaml:"max_line_width" json:"max_line_width"`
+	AvgLineWidth             *int              `yaml:"avg_line_width" json:"avg_line_width"`
+	MaxSentenceChars         *int              `yaml:"max_sentence_chars" json:"max_sentence_chars"`
+	MaxParagraphChars        *int              `yaml:"max_paragraph_chars" json:"max_paragraph_chars"`
+	MaxParagraphLines        *int              `yaml:"max_paragraph_lines" json:"max_paragraph_lines"`
+	MinReadability           *float64          `yaml:"min_readability" json:"min_readability"`
+	MaxGradeLevel            *float64          `yaml:"max_grade_level" json:"max_grade_level"`
+	MaxReadingTime           string            `yaml:"max_reading_time" json:"max_reading_time"`
+	MaxSpeakingTime          string            `yaml:"max_speaking_time" json:"max_speaking_time"`
+	NoTrailingSpaces         bool              `yaml:"no_trailing_spaces" json:"no_trailing_spaces"`
+	NoTabs                   bool              `yaml:"no_tabs" json:"no_tabs"`
+	NoFullwidthSpace         bool              `yaml:"no_fullwidth_space" json:"no_fullwidth_space"`
+	NoZeroWidthChars         bool              `yaml:"no_zero_width_chars" json:"no_zero_width_chars"`
+	NoBidiControls           bool              `yaml:"no_bidi_controls" json:"no_bidi_controls"`
+	NoNBSP                   bool              `yaml:"no_nbsp" json:"no_nbsp"`
+	RequireNFC               bool              `yaml:"require_nfc" json:"require_nfc"`
+	ForbiddenCodepoints      []string          `yaml:"forbidden_codepoints" json:"forbidden_codepoints"`
+	ZhTypography             ZhTypographyRules `yaml:"zh_typography" json:"zh_typography"`
+	ChineseVariant           string            `yaml:"chinese_variant" json:"chinese_variant"`
+	AllowedLanguages         []string          `yaml:"allowed_languages" json:"allowed_languages"`
+	RequireImageAlt          bool              `yaml:"require_image_alt" json:"require_image_alt"`
+	ForbidBareURLs           bool              `yaml:"forbid_bare_urls" json:"forbid_bare_urls"`
+	ForbidEmptyLinkText      bool              `yaml:"forbid_empty_link_text" json:"forbid_empty_link_text"`
+	ForbidGenericLinkText    bool              `yaml:"forbid_generic_link_text" json:"forbid_generic_link_text"`
+	GenericLinkTexts         []string          `yaml:"generic_link_texts" json:"generic_link_texts"`
+	Terminology              Terminology       `yaml:"terminology" json:"terminology"`
+	MaxConsecutiveBlankLines *int              `yaml:"max_consecutive_blank_lines" json:"max_consecutive_blank_lines"`
+	ForbiddenPatterns        []PatternRule     `yaml:"forbidden_patterns" json:"forbidden_patterns"`
+	RequiredPatterns         []PatternRule     `yaml:"required_patterns" json:"required_patterns"`
 }
 
+// SectionRule 选中章节的条件之间为“且”关系：标题条件（包含/正则/全等）、级别范围、文件 glob。
 type SectionRule struct {
 	HeadingContains string             `yaml:"heading_contains" json:"heading_contains"`
+	HeadingRegex    string             `yaml:"heading_regex" json:"heading_regex"`
+	HeadingEquals   string             `yaml:"heading_equals" json:"heading_equals"`
+	Level           int                `yaml:"level" json:"level"`
+	MinLevel        int                `yaml:"min_level" json:"min_level"`
+	MaxLevel        int                `yaml:"max_level" json:"max_level"`
+	Files           []string           `yaml:"files" json:"files"`
+	Match           string             `yaml:"match" json:"match"`
 	Rules           SectionScopedRules `yaml:"rules" json:"rules"`
 }
 
+// CorpusBudget 按 glob 限定一组文件的总量，如 prompts/** 合计不超过 20 万字符。
+type CorpusBudget struct {
+	Files         []string `yaml:"files" json:"files"`
+	MaxTotalChars *int     `yaml:"max_total_chars" json:"max_total_chars"`
+	MaxTotalFiles *int     `yaml:"max_total_files" json:"max_total_files"`
+}
+
+// RequiredFilesRule 要求匹配 dirs（glob）的每个目录都直接包含 files 中列出的文件。
+type RequiredFilesRule struct {
+	Dirs  []string `yaml:"dirs" json:"dirs"`
+	Files []string `yaml:"files" json:"files"`
+}
+
 type Rules struct {
-	MinChars                 *int          `yaml:"min_chars"`
-	MaxChars                 *int          `yaml:"max_chars"`
-	MinLines                 *int          `yaml:"min_lines"`
-	MaxLines                 *int          `yaml:"max_lines"`
-	MaxLineWidth             *int          `yaml:"max_line_width"`
-	AvgLineWidth             *int          `yaml:"avg_line_width"`
-	MaxFileSize              string        `yaml:"max_file_size"`
-	NoTrailingSpaces         bool          `yaml:"no_trailing_spaces"`
-	NoTabs                   bool          `yaml:"no_tabs"`
-	NoFullwidthSpace         bool          `yaml:"no_fullwidth_space"`
-	MaxConsecutiveBlankLines *int          `yaml:"max_consecutive_blank_lines"`
-	ForbiddenPatterns        []PatternRule `yaml:"forbidden_patterns"`
-	RequiredPatterns         []PatternRule `yaml:"required_patterns"`
-	AllowedExtensions        []string      `yaml:"allowed_extensions"`
-	IgnorePatterns           []string      `yaml:"ignore_patterns"`
-	SectionRules             []SectionRule `yaml:"section_rules"`
+	MinChars                 *int                `yaml:"min_chars"`
+	MaxChars                 *int                `yaml:"max_chars"`
+	MinLines                 *int                `yaml:"min_lines"`
+	MaxLines                 *int                `yaml:"max_lines"`
+	MaxLineWidth             *int                `yaml:"max_line_width"`
+	AvgLineWidth             *int                `yaml:"avg_line_width"`
+	MaxSentenceChars         *int                `yaml:"max_sentence_chars"`
+	MaxParagraphChars        *int                `yaml:"max_paragraph_chars"`
+	MaxParagraphLines        *int                `yaml:"max_paragraph_lines"`
+	MinReadability           *float64            `yaml:"min_readability"`
+	MaxGradeLevel            *float64            `yaml:"max_grade_level"`
+	MaxReadingTime           string              `yaml:"max_reading_time"`
+	MaxSpeakingTime          string              `yaml:"max_speaking_time"`
+	ReadingSpeed             ReadingSpeed        `yaml:"reading_speed"`
+	MaxFileSize              string              `yaml:"max_file_size"`
+	NoTrailingSpaces         bool                `yaml:"no_trailing_spaces"`
+	NoTabs                   bool                `yaml:"no_tabs"`
+	NoFullwidthSpace         bool                `yaml:"no_fullwidth_space"`
+	NoZeroWidthChars         bool                `yaml:"no_zero_width_chars"`
+	NoBidiControls           bool                `yaml:"no_bidi_controls"`
+	NoNBSP                   bool                `yaml:"no_nbsp"`
+	RequireNFC               bool                `yaml:"require_nfc"`
+	ForbiddenCodepoints      []string            `yaml:"forbidden_codepoints"`
+	ZhTypography             ZhTypographyRules   `yaml:"zh_typography"`
+	ChineseVariant           string              `yaml:"chinese_variant"`
+	AllowedLanguages         []string            `yaml:"allowed_languages"`
+	RequireImageAlt          bool                `yaml:"require_image_alt"`
+	ForbidBareURLs           bool                `yaml:"forbid_bare_urls"`
+	ForbidEmptyLinkText      bool                `yaml:"forbid_empty_link_text"`
+	ForbidGenericLinkText    bool                `yaml:"forbid_generic_link_text"`
+	GenericLinkTexts         []string            `yaml:"generic_link_texts"`
+	MaxConsecutiveBlankLines *int                `yaml:"max_consecutive_blank_lines"`
+	NoDuplicateLines         DuplicateRule       `yaml:"no_duplicate_lines"`
+	NoDuplicateParagraphs    DuplicateRule       `yaml:"no_duplicate_paragraphs"`
+	DuplicateContent         DuplicateRule       `yaml:"duplicate_content"`
+	Secrets                  SensitiveRule       `yaml:"secrets"`
+	PII                      SensitiveRule       `yaml:"pii"`
+	Terminology              Terminology         `yaml:"terminology"`
+	ForbiddenPatterns        []PatternRule       `yaml:"forbidden_patterns"`
+	RequiredPatterns         []PatternRule       `yaml:"required_patterns"`
+	ForbiddenWordsFile       WordListRule        `yaml:"forbidden_words_file"`
+	RequiredWordsFile        WordListRule        `yaml:"required_words_file"`
+	RequiredSections         RequiredSections    `yaml:"required_sections"`
+	ForbiddenSections        []string            `yaml:"forbidden_sections"`
+	MaxHeadingDepth          *int                `yaml:"max_heading_depth"`
+	NoSkippedHeadingLevels   bool                `yaml:"no_skipped_heading_levels"`
+	SingleH1                 bool                `yaml:"single_h1"`
+	UniqueHeadings           bool                `yaml:"unique_headings"`
+	MarkdownHTMLHeadings     bool                `yaml:"markdown_html_headings"`
+	SectionSyntax            SectionSyntax       `yaml:"section_syntax"`
+	SectionHeadingPattern    string              `yaml:"section_heading_pattern"`
+	CheckLocalLinks          bool                `yaml:"check_local_links"`
+	AllowedExtensions        []string            `yaml:"allowed_extensions"`
+	FilenamePattern          string              `yaml:"filename_pattern"`
+	MaxFilenameLength        *int                `yaml:"max_filename_length"`
+	MaxPathDepth             *int                `yaml:"max_path_depth"`
+	ForbidWhitespaceInNames  bool                `yaml:"forbid_whitespace_in_names"`
+	ForbidNonASCIINames      bool                `yaml:"forbid_non_ascii_names"`
+	RequiredFiles            []RequiredFilesRule `yaml:"required_files"`
+	MaxTotalChars            *int                `yaml:"max_total_chars"`
+	MaxTotalFiles            *int                `yaml:"max_total_files"`
+	MaxFilesPerDirectory     *int                `yaml:"max_files_per_directory"`
+	CorpusBudgets            []CorpusBudget      `yaml:"corpus_budgets"`
+	IgnorePatterns           []string            `yaml:"ignore_patterns"`
+	SectionRules             []SectionRule       `yaml:"section_rules"`
 }
 
 type Config struct {
@@ -76,9 +272,28 @@ func Load(path string) (Config, error) {
 	if err := dec.Decode(&cfg); err != nil {
 		return cfg, fmt.Errorf("解析配置文件失败：%w", err)
 	}
+	if err := cfg.Rules.loadFiles(filepath.Dir(path)); err != nil {
+		return cfg, err
+	}
 	return cfg, nil
 }
 
+// loadFiles 读取规则引用的外部文件（术语表、词表），相对路径相对 baseDir。
+func (r *Rules) loadFiles(baseDir string) error {
+	if err := r.Terminology.loadFile(baseDir); err != nil {
+		return err
+	}
+	for i := range r.SectionRules {
+		if err := r.SectionRules[i].Rules.Terminology.loadFile(baseDir); err != nil {
+			return err
+		}
+	}
+	if err := r.ForbiddenWordsFile.loadFile(baseDir, "forbidden_words_file"); err != nil {
+		return err
+	}
+	return r.RequiredWordsFile.loadFile(baseDir, "required_words_file")
+}
+
 var envExpr = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
 
 func expandEnv(src string) (string, error) {
@@ -136,3 +351,22 @@ func ParseSizeToBytes(s string) (int64, error) {
 	}
 	return n, nil
 }
+
+// ParseDurationSeconds 解析时长：纯数字按秒，其余按 Go 时长语法（如 90s、5m、1m30s）。
+func ParseDurationSeconds(s string) (int, error) {
+	v := strings.TrimSpace(s)
+	if v == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(v); err == nil {
+		if n < 0 {
+			return 0, fmt.Errorf("无效时长：%s", s)
+		}
+		return n, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("无效时长：%s", s)
+	}
+	return int(d.Seconds()), nil
+}
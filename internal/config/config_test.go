@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -41,3 +42,189 @@ func TestLoadKnownFields(t *testing.T) {
 		t.Fatalf("unexpected section rules max_chars: %#v", cfg.Rules.SectionRules[0].Rules.MaxChars)
 	}
 }
+
+func TestLoadRequiredSectionsForms(t *testing.T) {
+	tmp := t.TempDir()
+	cases := map[string]RequiredSections{
+		"rules:\n  required_sections: [背景, 目标]\n": {Headings: []string{"背景", "目标"}},
+		"rules:\n  required_sections:\n    headings: [背景, 目标]\n    ordered: true\n    level: 2\n    files: [\"design/*.md\"]\n": {
+			Headings: []string{"背景", "目标"}, Ordered: true, Level: 2, Files: []string{"design/*.md"},
+		},
+	}
+	for src, want := range cases {
+		p := filepath.Join(tmp, "c.yaml")
+		if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		cfg, err := Load(p)
+		if err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+		got := cfg.Rules.RequiredSections
+		if len(got.Headings) != 2 || got.Headings[1] != "目标" || got.Ordered != want.Ordered || got.Level != want.Level || len(got.Files) != len(want.Files) {
+			t.Fatalf("unexpected required_sections: %#v", got)
+		}
+	}
+}
+
+func TestLoadSectionSyntaxForms(t *testing.T) {
+	tmp := t.TempDir()
+	p := filepath.Join(tmp, "c.yaml")
+	if err := os.WriteFile(p, []byte("rules:\n  section_syntax: rst\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Load(p)
+	if err != nil || cfg.Rules.SectionSyntax.Default != "rst" {
+		t.Fatalf("unexpected scalar section_syntax: %#v err=%v", cfg.Rules.SectionSyntax, err)
+	}
+	if err := os.WriteFile(p, []byte("rules:\n  section_syntax:\n    TXT: custom\n    .org: org\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err = Load(p)
+	if err != nil || cfg.Rules.SectionSyntax.ByExt[".txt"] != "custom" || cfg.Rules.SectionSyntax.ByExt[".org"] != "org" {
+		t.Fatalf("unexpected mapping section_syntax: %#v err=%v", cfg.Rules.SectionSyntax, err)
+	}
+}
+
+func TestLoadTerminologyForms(t *testing.T) {
+	tmp := t.TempDir()
+	csv := "\ufeffuse,avoid,exceptions,case_sensitive\n# 注释\n登录,登陆|log in,登陆器,\nGitHub,github,,true\n"
+	if err := os.WriteFile(filepath.Join(tmp, "terms.csv"), []byte(csv), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "terms.yaml"), []byte("- use: 账号\n  avoid: [帐号]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	inline := filepath.Join(tmp, "inline.yaml")
+	if err := os.WriteFile(inline, []byte("rules:\n  terminology:\n    - use: 用户\n      avoid: [使用者]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(inline)
+	if err != nil {
+		t.Fatalf("load inline: %v", err)
+	}
+	if len(cfg.Rules.Terminology.Terms) != 1 || cfg.Rules.Terminology.Terms[0].Use != "用户" {
+		t.Fatalf("unexpected inline terms: %#v", cfg.Rules.Terminology)
+	}
+
+	withFile := filepath.Join(tmp, "file.yaml")
+	if err := os.WriteFile(withFile, []byte("rules:\n  terminology:\n    file: terms.csv\n    case_sensitive: false\n    terms:\n      - use: 用户\n        avoid: [使用者]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(withFile)
+	if err != nil {
+		t.Fatalf("load with file: %v", err)
+	}
+	terms := cfg.Rules.Terminology.Terms
+	if len(terms) != 3 || terms[1].Use != "登录" || len(terms[1].Avoid) != 2 || terms[1].Avoid[1] != "log in" || len(terms[1].Exceptions) != 1 {
+		t.Fatalf("unexpected csv terms: %#v", terms)
+	}
+	if terms[1].CaseSensitive != nil || terms[2].CaseSensitive == nil || !*terms[2].CaseSensitive {
+		t.Fatalf("unexpected csv case_sensitive: %#v", terms)
+	}
+
+	yamlTerms, err := LoadTerminologyFile(filepath.Join(tmp, "terms.yaml"))
+	if err != nil || len(yamlTerms) != 1 || yamlTerms[0].Avoid[0] != "帐号" {
+		t.Fatalf("unexpected yaml terms: %#v, %v", yamlTerms, err)
+	}
+
+	objFile := filepath.Join(tmp, "terms-object.yaml")
+	if err := os.WriteFile(objFile, []byte("case_sensitive: true\nterms:\n  - use: GitHub\n    avoid: [github]\n  - use: 登录\n    avoid: [登陆]\n    case_sensitive: false\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	objCfg := filepath.Join(tmp, "object.yaml")
+	if err := os.WriteFile(objCfg, []byte("rules:\n  terminology:\n    file: terms-object.yaml\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(objCfg)
+	if err != nil {
+		t.Fatalf("load object terminology file: %v", err)
+	}
+	terms = cfg.Rules.Terminology.Terms
+	if len(terms) != 2 || terms[0].CaseSensitive == nil || !*terms[0].CaseSensitive || terms[1].CaseSensitive == nil || *terms[1].CaseSensitive {
+		t.Fatalf("file-level case_sensitive should apply to entries without their own: %#v", terms)
+	}
+
+	section := filepath.Join(tmp, "section.yaml")
+	if err := os.WriteFile(section, []byte("rules:\n  section_rules:\n    - heading_equals: 发布说明\n      rules:\n        terminology:\n          file: terms.yaml\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(section)
+	if err != nil {
+		t.Fatalf("load section terminology: %v", err)
+	}
+	if terms := cfg.Rules.SectionRules[0].Rules.Terminology.Terms; len(terms) != 1 || terms[0].Use != "账号" {
+		t.Fatalf("section terminology file should be loaded: %#v", terms)
+	}
+
+	missing := filepath.Join(tmp, "missing.yaml")
+	if err := os.WriteFile(missing, []byte("rules:\n  terminology:\n    file: nope.csv\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(missing); err == nil || !strings.Contains(err.Error(), "terminology.file") {
+		t.Fatalf("expected terminology.file error, got %v", err)
+	}
+}
+
+func TestLoadPatternRuleOptions(t *testing.T) {
+	tmp := t.TempDir()
+	p := filepath.Join(tmp, "c.yaml")
+	src := "rules:\n  forbidden_patterns:\n    - pattern: \"a.b\"\n      literal: true\n      whole_word: true\n      max_count: 3\n      allow: [\"a\\\\.b\\\\.c\"]\n      message: 不要写 a.b\n      id: no_ab\n  required_patterns:\n    - pattern: Acme\n      min_count: 2\n"
+	if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(p)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	fp := cfg.Rules.ForbiddenPatterns[0]
+	if !fp.Literal || !fp.WholeWord || fp.MaxCount == nil || *fp.MaxCount != 3 || len(fp.Allow) != 1 || fp.Allow[0] != `a\.b\.c` || fp.Message != "不要写 a.b" || fp.ID != "no_ab" {
+		t.Fatalf("unexpected forbidden pattern: %#v", fp)
+	}
+	if rp := cfg.Rules.RequiredPatterns[0]; rp.MinCount == nil || *rp.MinCount != 2 {
+		t.Fatalf("unexpected required pattern: %#v", rp)
+	}
+}
+
+func TestLoadDuplicateRuleForms(t *testing.T) {
+	tmp := t.TempDir()
+	p := filepath.Join(tmp, "c.yaml")
+	src := "rules:\n  no_duplicate_lines: true\n  no_duplicate_paragraphs:\n    min_chars: 10\n  duplicate_content:\n    enabled: false\n    min_chars: 5\n"
+	if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(p)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	r := cfg.Rules
+	if !r.NoDuplicateLines.Enabled || r.NoDuplicateLines.MinChars != nil || r.NoDuplicateLines.MinCharsOr(20) != 20 {
+		t.Fatalf("unexpected no_duplicate_lines: %#v", r.NoDuplicateLines)
+	}
+	if !r.NoDuplicateParagraphs.Enabled || r.NoDuplicateParagraphs.MinCharsOr(40) != 10 {
+		t.Fatalf("mapping form should enable the rule: %#v", r.NoDuplicateParagraphs)
+	}
+	if r.DuplicateContent.Enabled || r.DuplicateContent.MinCharsOr(40) != 5 {
+		t.Fatalf("explicit enabled: false should be kept: %#v", r.DuplicateContent)
+	}
+}
+
+func TestLoadSensitiveRuleForms(t *testing.T) {
+	tmp := t.TempDir()
+	p := filepath.Join(tmp, "c.yaml")
+	src := "rules:\n  secrets:\n    exclude: [secrets.high_entropy]\n    min_entropy: 4.5\n  pii: true\n"
+	if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(p)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	r := cfg.Rules
+	if !r.Secrets.Enabled || len(r.Secrets.Exclude) != 1 || r.Secrets.MinEntropy == nil || *r.Secrets.MinEntropy != 4.5 {
+		t.Fatalf("mapping form should enable secrets: %#v", r.Secrets)
+	}
+	if !r.PII.Enabled || r.PII.Exclude != nil {
+		t.Fatalf("unexpected pii: %#v", r.PII)
+	}
+}
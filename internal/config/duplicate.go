@@ -0,0 +1,34 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// DuplicateRule 重复内容规则。YAML 中可直接写 true/false，也可写成带 min_chars 的对象（写对象即开启）；
+// 去掉所有空白后短于 min_chars 的行或段落不参与比较。
+type DuplicateRule struct {
+	Enabled  bool `yaml:"enabled" json:"enabled"`
+	MinChars *int `yaml:"min_chars" json:"min_chars"`
+}
+
+func (d *DuplicateRule) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&d.Enabled)
+	}
+	var p struct {
+		Enabled  *bool `yaml:"enabled"`
+		MinChars *int  `yaml:"min_chars"`
+	}
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	d.Enabled = p.Enabled == nil || *p.Enabled
+	d.MinChars = p.MinChars
+	return nil
+}
+
+// MinCharsOr 返回 min_chars，未设置时返回 def。
+func (d DuplicateRule) MinCharsOr(def int) int {
+	if d.MinChars != nil {
+		return *d.MinChars
+	}
+	return def
+}
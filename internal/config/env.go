@@ -49,6 +49,19 @@ func LoadRulesFromEnv(prefix string) (Rules, bool, error) {
 		*dst = &n
 		return nil
 	}
+	setFloatPtr := func(key string, dst **float64) error {
+		v, ok := os.LookupEnv(prefix + key)
+		if !ok {
+			return nil
+		}
+		has = true
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return fmt.Errorf("环境变量 %s%s 不是有效数字", prefix, key)
+		}
+		*dst = &f
+		return nil
+	}
 	setBool := func(key string, dst *bool) error {
 		v, ok := os.LookupEnv(prefix + key)
 		if !ok {
@@ -126,12 +139,70 @@ func LoadRulesFromEnv(prefix string) (Rules, bool, error) {
 	if err := setIntPtr("AVG_LINE_WIDTH", &r.AvgLineWidth); err != nil {
 		return Rules{}, false, err
 	}
+	if err := setIntPtr("MAX_SENTENCE_CHARS", &r.MaxSentenceChars); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setIntPtr("MAX_PARAGRAPH_CHARS", &r.MaxParagraphChars); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setIntPtr("MAX_PARAGRAPH_LINES", &r.MaxParagraphLines); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setFloatPtr("MIN_READABILITY", &r.MinReadability); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setFloatPtr("MAX_GRADE_LEVEL", &r.MaxGradeLevel); err != nil {
+		return Rules{}, false, err
+	}
+	speeds := []struct {
+		key string
+		dst *int
+	}{
+		{"READING_WPM", &r.ReadingSpeed.WordsPerMinute},
+		{"READING_CPM", &r.ReadingSpeed.CharsPerMinute},
+		{"SPEAKING_WPM", &r.ReadingSpeed.SpeakingWordsPerMinute},
+		{"SPEAKING_CPM", &r.ReadingSpeed.SpeakingCharsPerMinute},
+	}
+	for _, sp := range speeds {
+		var n *int
+		if err := setIntPtr(sp.key, &n); err != nil {
+			return Rules{}, false, err
+		}
+		if n != nil {
+			*sp.dst = *n
+		}
+	}
+	if err := setIntPtr("MAX_HEADING_DEPTH", &r.MaxHeadingDepth); err != nil {
+		return Rules{}, false, err
+	}
 	if err := setIntPtr("MAX_CONSECUTIVE_BLANK_LINES", &r.MaxConsecutiveBlankLines); err != nil {
 		return Rules{}, false, err
 	}
+	if err := setIntPtr("MAX_FILENAME_LENGTH", &r.MaxFilenameLength); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setIntPtr("MAX_PATH_DEPTH", &r.MaxPathDepth); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setIntPtr("MAX_TOTAL_CHARS", &r.MaxTotalChars); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setIntPtr("MAX_TOTAL_FILES", &r.MaxTotalFiles); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setIntPtr("MAX_FILES_PER_DIRECTORY", &r.MaxFilesPerDirectory); err != nil {
+		return Rules{}, false, err
+	}
 
 	setString("MAX_FILE_SIZE", &r.MaxFileSize)
+	setString("FILENAME_PATTERN", &r.FilenamePattern)
+	setString("MAX_READING_TIME", &r.MaxReadingTime)
+	setString("MAX_SPEAKING_TIME", &r.MaxSpeakingTime)
+	setString("CHINESE_VARIANT", &r.ChineseVariant)
+	setList("ALLOWED_LANGUAGES", &r.AllowedLanguages)
 	setList("ALLOWED_EXTENSIONS", &r.AllowedExtensions)
+	setList("REQUIRED_SECTIONS", &r.RequiredSections.Headings)
+	setList("FORBIDDEN_SECTIONS", &r.ForbiddenSections)
 	setList("IGNORE_PATTERNS", &r.IgnorePatterns)
 
 	if err := setBool("NO_TRAILING_SPACES", &r.NoTrailingSpaces); err != nil {
@@ -143,6 +214,73 @@ func LoadRulesFromEnv(prefix string) (Rules, bool, error) {
 	if err := setBool("NO_FULLWIDTH_SPACE", &r.NoFullwidthSpace); err != nil {
 		return Rules{}, false, err
 	}
+	if err := setBool("NO_ZERO_WIDTH_CHARS", &r.NoZeroWidthChars); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("NO_BIDI_CONTROLS", &r.NoBidiControls); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("NO_NBSP", &r.NoNBSP); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("REQUIRE_NFC", &r.RequireNFC); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("REQUIRED_SECTIONS_ORDERED", &r.RequiredSections.Ordered); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("NO_SKIPPED_HEADING_LEVELS", &r.NoSkippedHeadingLevels); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("SINGLE_H1", &r.SingleH1); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("UNIQUE_HEADINGS", &r.UniqueHeadings); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("MARKDOWN_HTML_HEADINGS", &r.MarkdownHTMLHeadings); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("CHECK_LOCAL_LINKS", &r.CheckLocalLinks); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("FORBID_WHITESPACE_IN_NAMES", &r.ForbidWhitespaceInNames); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("FORBID_NON_ASCII_NAMES", &r.ForbidNonASCIINames); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("REQUIRE_IMAGE_ALT", &r.RequireImageAlt); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("FORBID_BARE_URLS", &r.ForbidBareURLs); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("FORBID_EMPTY_LINK_TEXT", &r.ForbidEmptyLinkText); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setBool("FORBID_GENERIC_LINK_TEXT", &r.ForbidGenericLinkText); err != nil {
+		return Rules{}, false, err
+	}
+	setList("GENERIC_LINK_TEXTS", &r.GenericLinkTexts)
+	setList("FORBIDDEN_CODEPOINTS", &r.ForbiddenCodepoints)
+	if v, ok := os.LookupEnv(prefix + "SECTION_SYNTAX"); ok {
+		has = true
+		ss, err := ParseSectionSyntax(v)
+		if err != nil {
+			return Rules{}, false, fmt.Errorf("环境变量 %sSECTION_SYNTAX 配置错误：%w", prefix, err)
+		}
+		r.SectionSyntax = ss
+	}
+	setString("SECTION_HEADING_PATTERN", &r.SectionHeadingPattern)
+	if v, ok := os.LookupEnv(prefix + "ZH_TYPOGRAPHY"); ok {
+		has = true
+		z, err := ParseZhTypography(splitCSV(v))
+		if err != nil {
+			return Rules{}, false, fmt.Errorf("环境变量 %sZH_TYPOGRAPHY 配置错误：%w", prefix, err)
+		}
+		r.ZhTypography = z
+	}
 
 	setPatterns("FORBIDDEN_PATTERNS", true, &r.ForbiddenPatterns)
 	setPatterns("FORBIDDEN_PATTERNS_I", false, &r.ForbiddenPatterns)
@@ -151,10 +289,100 @@ func LoadRulesFromEnv(prefix string) (Rules, bool, error) {
 	if err := setSectionRules("SECTION_RULES", &r.SectionRules); err != nil {
 		return Rules{}, false, err
 	}
+	if v, ok := os.LookupEnv(prefix + "REQUIRED_FILES"); ok && strings.TrimSpace(v) != "" {
+		has = true
+		if err := json.Unmarshal([]byte(v), &r.RequiredFiles); err != nil {
+			return Rules{}, false, fmt.Errorf("环境变量 %sREQUIRED_FILES 不是有效 JSON：%w", prefix, err)
+		}
+	}
+	if v, ok := os.LookupEnv(prefix + "CORPUS_BUDGETS"); ok && strings.TrimSpace(v) != "" {
+		has = true
+		if err := json.Unmarshal([]byte(v), &r.CorpusBudgets); err != nil {
+			return Rules{}, false, fmt.Errorf("环境变量 %sCORPUS_BUDGETS 不是有效 JSON：%w", prefix, err)
+		}
+	}
+	if v, ok := os.LookupEnv(prefix + "TERMINOLOGY"); ok && strings.TrimSpace(v) != "" {
+		has = true
+		if err := json.Unmarshal([]byte(v), &r.Terminology.Terms); err != nil {
+			return Rules{}, false, fmt.Errorf("环境变量 %sTERMINOLOGY 不是有效 JSON：%w", prefix, err)
+		}
+	}
+	setString("TERMINOLOGY_FILE", &r.Terminology.File)
+	if err := setBool("TERMINOLOGY_CASE_SENSITIVE", &r.Terminology.CaseSensitive); err != nil {
+		return Rules{}, false, err
+	}
+	for _, wl := range []struct {
+		key string
+		dst *WordListRule
+	}{{"FORBIDDEN_WORDS", &r.ForbiddenWordsFile}, {"REQUIRED_WORDS", &r.RequiredWordsFile}} {
+		setString(wl.key+"_FILE", &wl.dst.File)
+		if v, ok := os.LookupEnv(prefix + wl.key + "_CASE_SENSITIVE"); ok {
+			has = true
+			b, err := parseBool(v)
+			if err != nil {
+				return Rules{}, false, fmt.Errorf("环境变量 %s%s_CASE_SENSITIVE 不是有效布尔值", prefix, wl.key)
+			}
+			wl.dst.CaseSensitive = &b
+		}
+		if err := setBool(wl.key+"_WHOLE_WORD", &wl.dst.WholeWord); err != nil {
+			return Rules{}, false, err
+		}
+	}
+	for _, dr := range []struct {
+		key string
+		dst *DuplicateRule
+	}{{"NO_DUPLICATE_LINES", &r.NoDuplicateLines}, {"NO_DUPLICATE_PARAGRAPHS", &r.NoDuplicateParagraphs}, {"DUPLICATE_CONTENT", &r.DuplicateContent}} {
+		if err := setBool(dr.key, &dr.dst.Enabled); err != nil {
+			return Rules{}, false, err
+		}
+		if err := setIntPtr(dr.key+"_MIN_CHARS", &dr.dst.MinChars); err != nil {
+			return Rules{}, false, err
+		}
+	}
+	for _, sr := range []struct {
+		key string
+		dst *SensitiveRule
+	}{{"SECRETS", &r.Secrets}, {"PII", &r.PII}} {
+		if err := setBool(sr.key, &sr.dst.Enabled); err != nil {
+			return Rules{}, false, err
+		}
+		setList(sr.key+"_EXCLUDE", &sr.dst.Exclude)
+	}
+	if err := setFloatPtr("SECRETS_MIN_ENTROPY", &r.Secrets.MinEntropy); err != nil {
+		return Rules{}, false, err
+	}
+	if err := setIntPtr("SECRETS_MIN_LENGTH", &r.Secrets.MinLength); err != nil {
+		return Rules{}, false, err
+	}
+	if err := r.loadFiles(""); err != nil {
+		return Rules{}, false, fmt.Errorf("环境变量规则配置错误：%w", err)
+	}
 
 	return r, has, nil
 }
 
+// ParseZhTypography 把检查项名称列表转换为中文排版规则组，all 表示全部开启。
+func ParseZhTypography(names []string) (ZhTypographyRules, error) {
+	z := ZhTypographyRules{}
+	for _, n := range names {
+		switch strings.ToLower(n) {
+		case "all":
+			z = ZhTypographyRules{CJKLatinSpace: true, FullwidthPunctuation: true, NoHalfwidthCommaAfterHan: true, NoDuplicatePunctuation: true}
+		case "cjk_latin_space":
+			z.CJKLatinSpace = true
+		case "fullwidth_punctuation":
+			z.FullwidthPunctuation = true
+		case "no_halfwidth_comma_after_han":
+			z.NoHalfwidthCommaAfterHan = true
+		case "no_duplicate_punctuation":
+			z.NoDuplicatePunctuation = true
+		default:
+			return ZhTypographyRules{}, fmt.Errorf("未知检查项：%s", n)
+		}
+	}
+	return z, nil
+}
+
 func splitCSV(v string) []string {
 	parts := strings.Split(v, ",")
 	out := make([]string, 0, len(parts))
@@ -92,3 +92,187 @@ func TestLoadRulesFromEnvInvalidSectionRulesJSON(t *testing.T) {
 		t.Fatalf("expected invalid section rules json error")
 	}
 }
+
+func TestLoadRulesFromEnvUnicodeRules(t *testing.T) {
+	t.Setenv("TWC_NO_ZERO_WIDTH_CHARS", "true")
+	t.Setenv("TWC_REQUIRE_NFC", "1")
+	t.Setenv("TWC_FORBIDDEN_CODEPOINTS", "U+200B, U+2000-U+200F")
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load from env failed: ok=%v err=%v", ok, err)
+	}
+	if !r.NoZeroWidthChars || !r.RequireNFC {
+		t.Fatalf("expected unicode bool rules enabled: %+v", r)
+	}
+	if len(r.ForbiddenCodepoints) != 2 || r.ForbiddenCodepoints[1] != "U+2000-U+200F" {
+		t.Fatalf("bad forbidden codepoints: %#v", r.ForbiddenCodepoints)
+	}
+}
+
+func TestLoadRulesFromEnvAllowedLanguages(t *testing.T) {
+	t.Setenv("TWC_ALLOWED_LANGUAGES", "zh, en")
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load from env failed: ok=%v err=%v", ok, err)
+	}
+	if len(r.AllowedLanguages) != 2 || r.AllowedLanguages[1] != "en" {
+		t.Fatalf("bad allowed languages: %#v", r.AllowedLanguages)
+	}
+}
+
+func TestLoadRulesFromEnvSectionSyntax(t *testing.T) {
+	t.Setenv("TWC_SECTION_SYNTAX", "rst, txt=custom")
+	t.Setenv("TWC_SECTION_HEADING_PATTERN", `^第(?P<level>\d+)章 (?P<title>.+)$`)
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load from env failed: ok=%v err=%v", ok, err)
+	}
+	if r.SectionSyntax.Default != "rst" || r.SectionSyntax.ByExt[".txt"] != "custom" || r.SectionHeadingPattern == "" {
+		t.Fatalf("unexpected section syntax: %#v %q", r.SectionSyntax, r.SectionHeadingPattern)
+	}
+	if _, err := ParseSectionSyntax("rst,org"); err == nil {
+		t.Fatalf("expected error for two defaults")
+	}
+}
+
+func TestLoadRulesFromEnvZhTypography(t *testing.T) {
+	t.Setenv("TWC_ZH_TYPOGRAPHY", "cjk_latin_space,no_duplicate_punctuation")
+	r, _, err := LoadRulesFromEnv("TWC_")
+	if err != nil {
+		t.Fatalf("load from env failed: %v", err)
+	}
+	if !r.ZhTypography.CJKLatinSpace || !r.ZhTypography.NoDuplicatePunctuation || r.ZhTypography.FullwidthPunctuation {
+		t.Fatalf("unexpected zh typography: %+v", r.ZhTypography)
+	}
+
+	t.Setenv("TWC_ZH_TYPOGRAPHY", "bogus")
+	if _, _, err := LoadRulesFromEnv("TWC_"); err == nil {
+		t.Fatalf("expected unknown check error")
+	}
+}
+
+func TestLoadRulesFromEnvTerminology(t *testing.T) {
+	tmp := t.TempDir()
+	p := filepath.Join(tmp, "terms.csv")
+	if err := os.WriteFile(p, []byte("账号,帐号\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TWC_TERMINOLOGY", `[{"use":"登录","avoid":["登陆"]}]`)
+	t.Setenv("TWC_TERMINOLOGY_FILE", p)
+	t.Setenv("TWC_TERMINOLOGY_CASE_SENSITIVE", "true")
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load env terminology: ok=%v err=%v", ok, err)
+	}
+	if len(r.Terminology.Terms) != 2 || r.Terminology.Terms[1].Use != "账号" || !r.Terminology.CaseSensitive {
+		t.Fatalf("unexpected terminology: %#v", r.Terminology)
+	}
+
+	t.Setenv("TWC_TERMINOLOGY", "{")
+	if _, _, err := LoadRulesFromEnv("TWC_"); err == nil {
+		t.Fatal("expected invalid terminology json error")
+	}
+}
+
+func TestLoadRulesFromEnvWordLists(t *testing.T) {
+	tmp := t.TempDir()
+	p := filepath.Join(tmp, "words.txt")
+	if err := os.WriteFile(p, []byte("\ufeff# 注释\n赌博\n\n赌博\n spam \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TWC_FORBIDDEN_WORDS_FILE", p)
+	t.Setenv("TWC_FORBIDDEN_WORDS_CASE_SENSITIVE", "false")
+	t.Setenv("TWC_FORBIDDEN_WORDS_WHOLE_WORD", "true")
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load env word list: ok=%v err=%v", ok, err)
+	}
+	w := r.ForbiddenWordsFile
+	if len(w.Words) != 2 || w.Words[1] != "spam" || w.CaseSensitive == nil || *w.CaseSensitive || !w.WholeWord {
+		t.Fatalf("unexpected word list: %#v", w)
+	}
+
+	t.Setenv("TWC_FORBIDDEN_WORDS_FILE", filepath.Join(tmp, "missing.txt"))
+	if _, _, err := LoadRulesFromEnv("TWC_"); err == nil || !strings.Contains(err.Error(), "forbidden_words_file") {
+		t.Fatalf("expected forbidden_words_file error, got %v", err)
+	}
+}
+
+func TestLoadRulesFromEnvDuplicateRules(t *testing.T) {
+	t.Setenv("TWC_NO_DUPLICATE_LINES", "true")
+	t.Setenv("TWC_NO_DUPLICATE_LINES_MIN_CHARS", "8")
+	t.Setenv("TWC_DUPLICATE_CONTENT", "true")
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load env duplicate rules: ok=%v err=%v", ok, err)
+	}
+	if !r.NoDuplicateLines.Enabled || r.NoDuplicateLines.MinCharsOr(20) != 8 || !r.DuplicateContent.Enabled || r.NoDuplicateParagraphs.Enabled {
+		t.Fatalf("unexpected duplicate rules: %#v %#v %#v", r.NoDuplicateLines, r.NoDuplicateParagraphs, r.DuplicateContent)
+	}
+	t.Setenv("TWC_NO_DUPLICATE_LINES_MIN_CHARS", "x")
+	if _, _, err := LoadRulesFromEnv("TWC_"); err == nil {
+		t.Fatalf("expected invalid min chars error")
+	}
+}
+
+func TestLoadRulesFromEnvCorpusRules(t *testing.T) {
+	t.Setenv("TWC_MAX_TOTAL_CHARS", "200000")
+	t.Setenv("TWC_MAX_FILES_PER_DIRECTORY", "50")
+	t.Setenv("TWC_CORPUS_BUDGETS", `[{"files":["prompts/**"],"max_total_chars":1000}]`)
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load env corpus rules: ok=%v err=%v", ok, err)
+	}
+	if r.MaxTotalChars == nil || *r.MaxTotalChars != 200000 || r.MaxFilesPerDirectory == nil || *r.MaxFilesPerDirectory != 50 || r.MaxTotalFiles != nil {
+		t.Fatalf("unexpected corpus limits: %#v", r)
+	}
+	if len(r.CorpusBudgets) != 1 || r.CorpusBudgets[0].Files[0] != "prompts/**" || *r.CorpusBudgets[0].MaxTotalChars != 1000 {
+		t.Fatalf("unexpected corpus budgets: %#v", r.CorpusBudgets)
+	}
+	t.Setenv("TWC_CORPUS_BUDGETS", `{`)
+	if _, _, err := LoadRulesFromEnv("TWC_"); err == nil || !strings.Contains(err.Error(), "CORPUS_BUDGETS") {
+		t.Fatalf("expected CORPUS_BUDGETS error, got %v", err)
+	}
+}
+
+func TestLoadRulesFromEnvPathRules(t *testing.T) {
+	t.Setenv("TWC_FILENAME_PATTERN", "^[a-z-]+\\.md$")
+	t.Setenv("TWC_MAX_PATH_DEPTH", "4")
+	t.Setenv("TWC_FORBID_NON_ASCII_NAMES", "true")
+	t.Setenv("TWC_REQUIRED_FILES", `[{"dirs":["docs/**"],"files":["README.md"]}]`)
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load env path rules: ok=%v err=%v", ok, err)
+	}
+	if r.FilenamePattern != `^[a-z-]+\.md$` || r.MaxPathDepth == nil || *r.MaxPathDepth != 4 || !r.ForbidNonASCIINames || r.ForbidWhitespaceInNames || r.MaxFilenameLength != nil {
+		t.Fatalf("unexpected path rules: %#v", r)
+	}
+	if len(r.RequiredFiles) != 1 || r.RequiredFiles[0].Dirs[0] != "docs/**" || r.RequiredFiles[0].Files[0] != "README.md" {
+		t.Fatalf("unexpected required files: %#v", r.RequiredFiles)
+	}
+	t.Setenv("TWC_REQUIRED_FILES", `[`)
+	if _, _, err := LoadRulesFromEnv("TWC_"); err == nil || !strings.Contains(err.Error(), "REQUIRED_FILES") {
+		t.Fatalf("expected REQUIRED_FILES error, got %v", err)
+	}
+}
+
+func TestLoadRulesFromEnvSensitiveRules(t *testing.T) {
+	t.Setenv("TWC_SECRETS", "true")
+	t.Setenv("TWC_SECRETS_EXCLUDE", "secrets.jwt,secrets.high_entropy")
+	t.Setenv("TWC_SECRETS_MIN_LENGTH", "32")
+	t.Setenv("TWC_PII_EXCLUDE", "pii.email")
+	r, ok, err := LoadRulesFromEnv("TWC_")
+	if err != nil || !ok {
+		t.Fatalf("load env sensitive rules: ok=%v err=%v", ok, err)
+	}
+	if !r.Secrets.Enabled || len(r.Secrets.Exclude) != 2 || r.Secrets.MinLength == nil || *r.Secrets.MinLength != 32 || r.Secrets.MinEntropy != nil {
+		t.Fatalf("unexpected secrets: %#v", r.Secrets)
+	}
+	if r.PII.Enabled || len(r.PII.Exclude) != 1 {
+		t.Fatalf("unexpected pii: %#v", r.PII)
+	}
+	t.Setenv("TWC_SECRETS_MIN_ENTROPY", "high")
+	if _, _, err := LoadRulesFromEnv("TWC_"); err == nil || !strings.Contains(err.Error(), "SECRETS_MIN_ENTROPY") {
+		t.Fatalf("expected SECRETS_MIN_ENTROPY error, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// SensitiveRule 敏感信息规则组（secrets / pii）。YAML 中可直接写 true/false，也可写成对象（写对象即开启）；
+// exclude 列出不检查的子规则（如 secrets.high_entropy）。min_entropy / min_length 只用于 secrets.high_entropy。
+type SensitiveRule struct {
+	Enabled    bool     `yaml:"enabled" json:"enabled"`
+	Exclude    []string `yaml:"exclude" json:"exclude"`
+	MinEntropy *float64 `yaml:"min_entropy" json:"min_entropy"`
+	MinLength  *int     `yaml:"min_length" json:"min_length"`
+}
+
+func (s *SensitiveRule) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&s.Enabled)
+	}
+	var p struct {
+		Enabled    *bool    `yaml:"enabled"`
+		Exclude    []string `yaml:"exclude"`
+		MinEntropy *float64 `yaml:"min_entropy"`
+		MinLength  *int     `yaml:"min_length"`
+	}
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	s.Enabled = p.Enabled == nil || *p.Enabled
+	s.Exclude = p.Exclude
+	s.MinEntropy = p.MinEntropy
+	s.MinLength = p.MinLength
+	return nil
+}
@@ -25,3 +25,30 @@ func TestParseSizeToBytes(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestParseDurationSeconds(t *testing.T) {
+	cases := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"90", 90},
+		{"90s", 90},
+		{"5m", 300},
+		{"1m30s", 90},
+	}
+	for _, c := range cases {
+		got, err := ParseDurationSeconds(c.in)
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c.in, err)
+		}
+		if got != c.want {
+			t.Fatalf("parse %s got %d want %d", c.in, got, c.want)
+		}
+	}
+	for _, bad := range []string{"five", "-3", "-1m"} {
+		if _, err := ParseDurationSeconds(bad); err == nil {
+			t.Fatalf("expected error for %s", bad)
+		}
+	}
+}
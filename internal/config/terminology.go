@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TermEntry 术语条目：Avoid 中的写法应替换为 Use；Exceptions 中的短语里出现的 Avoid 不报。
+type TermEntry struct {
+	Use           string   `yaml:"use" json:"use"`
+	Avoid         []string `yaml:"avoid" json:"avoid"`
+	CaseSensitive *bool    `yaml:"case_sensitive" json:"case_sensitive"`
+	Exceptions    []string `yaml:"exceptions" json:"exceptions"`
+}
+
+// Terminology 术语表。YAML 中可直接写条目列表，也可写成带 file/case_sensitive/terms 的对象；
+// file 指向 YAML 或 CSV 术语文件，相对路径相对配置文件所在目录，加载后追加到 Terms。
+type Terminology struct {
+	File          string      `yaml:"file" json:"file"`
+	CaseSensitive bool        `yaml:"case_sensitive" json:"case_sensitive"`
+	Terms         []TermEntry `yaml:"terms" json:"terms"`
+}
+
+func (t *Terminology) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		return node.Decode(&t.Terms)
+	}
+	type plain Terminology
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*t = Terminology(p)
+	return nil
+}
+
+// loadFile 读取 File 指向的术语文件并追加到 Terms；baseDir 用于解析相对路径。
+func (t *Terminology) loadFile(baseDir string) error {
+	p := strings.TrimSpace(t.File)
+	if p == "" {
+		return nil
+	}
+	if !filepath.IsAbs(p) && baseDir != "" {
+		p = filepath.Join(baseDir, p)
+	}
+	terms, err := LoadTerminologyFile(p)
+	if err != nil {
+		return fmt.Errorf("terminology.file 加载失败：%w", err)
+	}
+	t.Terms = append(t.Terms, terms...)
+	return nil
+}
+
+// LoadTerminologyFile 读取术语文件。.csv 每行为 use,avoid,exceptions,case_sensitive，
+// avoid 与 exceptions 内多个值用 | 分隔，首行为 use 开头的表头时跳过，# 开头为注释；
+// 其他扩展名按 YAML 解析，可以是条目列表，也可以是带 terms 键的对象；对象中的 case_sensitive
+// 写入没有单独设置的条目。
+func LoadTerminologyFile(path string) ([]TermEntry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return parseTerminologyCSV(string(b))
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败：%w", path, err)
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+	var t Terminology
+	if err := node.Content[0].Decode(&t); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败：%w", path, err)
+	}
+	// 对象形式文件里的 case_sensitive 作用于本文件的条目，条目自己写了的优先
+	if node.Content[0].Kind == yaml.MappingNode {
+		var flag struct {
+			CaseSensitive *bool `yaml:"case_sensitive"`
+		}
+		if err := node.Content[0].Decode(&flag); err != nil {
+			return nil, fmt.Errorf("解析 %s 失败：%w", path, err)
+		}
+		if flag.CaseSensitive != nil {
+			for i := range t.Terms {
+				if t.Terms[i].CaseSensitive == nil {
+					cs := *flag.CaseSensitive
+					t.Terms[i].CaseSensitive = &cs
+				}
+			}
+		}
+	}
+	return t.Terms, nil
+}
+
+func parseTerminologyCSV(src string) ([]TermEntry, error) {
+	r := csv.NewReader(strings.NewReader(strings.TrimPrefix(src, "\ufeff")))
+	r.FieldsPerRecord = -1
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析 CSV 失败：%w", err)
+	}
+	out := make([]TermEntry, 0, len(records))
+	for i, rec := range records {
+		if i == 0 && len(rec) > 0 && strings.EqualFold(strings.TrimSpace(rec[0]), "use") {
+			continue
+		}
+		if len(rec) < 2 {
+			return nil, fmt.Errorf("CSV 第 %d 行至少需要 use,avoid 两列", i+1)
+		}
+		e := TermEntry{Use: strings.TrimSpace(rec[0]), Avoid: splitPipe(rec[1])}
+		if len(rec) > 2 {
+			e.Exceptions = splitPipe(rec[2])
+		}
+		if len(rec) > 3 && strings.TrimSpace(rec[3]) != "" {
+			cs, err := strconv.ParseBool(strings.TrimSpace(rec[3]))
+			if err != nil {
+				return nil, fmt.Errorf("CSV 第 %d 行 case_sensitive 不是有效布尔值：%s", i+1, rec[3])
+			}
+			e.CaseSensitive = &cs
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func splitPipe(v string) []string {
+	out := make([]string, 0)
+	for _, p := range strings.Split(v, "|") {
+		if s := strings.TrimSpace(p); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
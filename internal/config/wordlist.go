@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WordListRule 词表规则。YAML 中可直接写词表文件路径，也可写成带 file/case_sensitive/whole_word 的对象；
+// 文件为纯文本，每行一个词，空行与 # 开头的行忽略。Words 在加载配置时从 File 读入。
+type WordListRule struct {
+	File          string   `yaml:"file" json:"file"`
+	CaseSensitive *bool    `yaml:"case_sensitive" json:"case_sensitive"`
+	WholeWord     bool     `yaml:"whole_word" json:"whole_word"`
+	Words         []string `yaml:"-" json:"-"`
+}
+
+func (w *WordListRule) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&w.File)
+	}
+	type plain WordListRule
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*w = WordListRule(p)
+	return nil
+}
+
+// Any 判断是否配置了词表。
+func (w WordListRule) Any() bool {
+	return strings.TrimSpace(w.File) != "" || len(w.Words) > 0
+}
+
+// loadFile 读取 File 指向的词表；baseDir 用于解析相对路径，name 用于错误信息。
+func (w *WordListRule) loadFile(baseDir, name string) error {
+	p := strings.TrimSpace(w.File)
+	if p == "" {
+		return nil
+	}
+	if !filepath.IsAbs(p) && baseDir != "" {
+		p = filepath.Join(baseDir, p)
+	}
+	words, err := LoadWordList(p)
+	if err != nil {
+		return fmt.Errorf("%s 加载失败：%w", name, err)
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("%s 加载失败：%s 中没有任何词", name, p)
+	}
+	w.Words = words
+	return nil
+}
+
+// LoadWordList 读取纯文本词表：每行一个词，去掉首尾空白，跳过空行、# 注释与重复项。
+func LoadWordList(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	src := strings.TrimPrefix(string(b), "\ufeff")
+	seen := map[string]struct{}{}
+	out := make([]string, 0)
+	for _, ln := range strings.Split(src, "\n") {
+		s := strings.TrimSpace(ln)
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out, nil
+}
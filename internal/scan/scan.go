@@ -38,6 +38,7 @@ type GitIgnoreMatcher struct {
 
 type ScanResult struct {
 	Files  []string
+	Dirs   []string // 遍历到的未忽略目录（含输入目录本身），空目录也在内
 	Errors []ScanError
 }
 
@@ -49,6 +50,7 @@ type ScanError struct {
 
 func Collect(opts Options) ScanResult {
 	m := make(map[string]struct{})
+	dirs := make(map[string]struct{})
 	var errs []ScanError
 	matchers := loadGitIgnoreMatchers(opts)
 
@@ -72,7 +74,7 @@ func Collect(opts Options) ScanResult {
 			continue
 		}
 		if info.IsDir() {
-			walkDir(abs, opts, matchers, m, &errs)
+			walkDir(abs, opts, matchers, m, dirs, &errs)
 			continue
 		}
 		if _, ok := defaultIgnoreFiles[filepath.Base(abs)]; ok {
@@ -89,10 +91,15 @@ func Collect(opts Options) ScanResult {
 		files = append(files, p)
 	}
 	sort.Strings(files)
-	return ScanResult{Files: files, Errors: errs}
+	dirList := make([]string, 0, len(dirs))
+	for d := range dirs {
+		dirList = append(dirList, d)
+	}
+	sort.Strings(dirList)
+	return ScanResult{Files: files, Dirs: dirList, Errors: errs}
 }
 
-func walkDir(root string, opts Options, matchers []GitIgnoreMatcher, out map[string]struct{}, errs *[]ScanError) {
+func walkDir(root string, opts Options, matchers []GitIgnoreMatcher, out, dirs map[string]struct{}, errs *[]ScanError) {
 	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			*errs = append(*errs, ScanError{Code: "walk_error", Path: path, Detail: err.Error()})
@@ -106,6 +113,7 @@ func walkDir(root string, opts Options, matchers []GitIgnoreMatcher, out map[str
 			if isIgnored(path, true, opts, matchers) {
 				return fs.SkipDir
 			}
+			dirs[path] = struct{}{}
 			return nil
 		}
 		if d.Type()&os.ModeSymlink != 0 && !opts.FollowSymlinks {
@@ -73,6 +73,9 @@ func TestCollectAndIgnore(t *testing.T) {
 	if !hasOK {
 		t.Fatalf("ok.txt should be present: %#v", res.Files)
 	}
+	if len(res.Dirs) != 2 || res.Dirs[0] != tmp || res.Dirs[1] != filepath.Join(tmp, "sub") {
+		t.Fatalf("ignored directories should not be listed: %#v", res.Dirs)
+	}
 }
 
 func TestCollectDirectDSStore(t *testing.T) {
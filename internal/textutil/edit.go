@@ -0,0 +1,130 @@
+package textutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+// TextEdit 描述一处行内替换：把 Line 行 [StartColumn, EndColumn) 的字符替换为 Replacement。
+// 行号从 1 开始，列号为 rune 列号（从 1 开始）；StartColumn == EndColumn 表示插入。
+type TextEdit struct {
+	Line        int    `json:"line"`
+	StartColumn int    `json:"start_column"`
+	EndColumn   int    `json:"end_column"`
+	Replacement string `json:"replacement"`
+}
+
+// ApplyEdits 把 edits 应用到 text，保留原有换行符；与已应用编辑重叠的编辑会被跳过，
+// 但与已应用编辑完全相同（同一区间、同一替换文本）的编辑只应用一次，并同样标记为已生效。
+// 返回修改后的文本，以及每条编辑是否生效（与 edits 下标一一对应）。
+func ApplyEdits(text string, edits []TextEdit) (string, []bool) {
+	applied := make([]bool, len(edits))
+	if len(edits) == 0 {
+		return text, applied
+	}
+	lines, endings := splitLinesKeepEnding(text)
+
+	order := make([]int, len(edits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ea, eb := edits[order[a]], edits[order[b]]
+		if ea.Line != eb.Line {
+			return ea.Line < eb.Line
+		}
+		return ea.StartColumn < eb.StartColumn
+	})
+
+	byLine := map[int][]int{}
+	for _, i := range order {
+		e := edits[i]
+		if e.Line < 1 || e.Line > len(lines) || e.StartColumn < 1 || e.EndColumn < e.StartColumn {
+			continue
+		}
+		byLine[e.Line] = append(byLine[e.Line], i)
+	}
+
+	for ln, idxs := range byLine {
+		runes := []rune(lines[ln-1])
+		var b strings.Builder
+		cur := 0
+		lastEnd := -1
+		last := -1
+		for _, i := range idxs {
+			e := edits[i]
+			start, end := e.StartColumn-1, e.EndColumn-1
+			if end > len(runes) {
+				continue
+			}
+			if last >= 0 && e == edits[last] {
+				applied[i] = true
+				continue
+			}
+			// 同一位置的插入或区间重叠都只保留第一条
+			if start < lastEnd || (start == lastEnd && start == end) {
+				continue
+			}
+			b.WriteString(string(runes[cur:start]))
+			b.WriteString(e.Replacement)
+			cur = end
+			lastEnd = end
+			last = i
+			applied[i] = true
+		}
+		b.WriteString(string(runes[cur:]))
+		lines[ln-1] = b.String()
+	}
+
+	var out strings.Builder
+	for i, ln := range lines {
+		out.WriteString(ln)
+		out.WriteString(endings[i])
+	}
+	return out.String(), applied
+}
+
+// Encode 把文本按 Decode 识别出的编码写回字节。
+func Encode(text, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", "utf-8":
+		return []byte(text), nil
+	case "gb18030":
+		return simplifiedchinese.GB18030.NewEncoder().Bytes([]byte(text))
+	case "gbk":
+		return simplifiedchinese.GBK.NewEncoder().Bytes([]byte(text))
+	default:
+		return nil, fmt.Errorf("不支持写回的编码：%s", encoding)
+	}
+}
+
+func splitLinesKeepEnding(text string) ([]string, []string) {
+	lines := make([]string, 0)
+	endings := make([]string, 0)
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\n':
+			lines = append(lines, text[start:i])
+			endings = append(endings, "\n")
+			start = i + 1
+		case '\r':
+			lines = append(lines, text[start:i])
+			if i+1 < len(text) && text[i+1] == '\n' {
+				endings = append(endings, "\r\n")
+				i++
+			} else {
+				endings = append(endings, "\r")
+			}
+			start = i + 1
+		}
+	}
+	if start < len(text) {
+		lines = append(lines, text[start:])
+		endings = append(endings, "")
+	}
+	return lines, endings
+}
@@ -0,0 +1,41 @@
+package textutil
+
+import (
+	"testing"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+func TestApplyEdits(t *testing.T) {
+	text := "使用Go\r\n你好,世界\r\n"
+	out, applied := ApplyEdits(text, []TextEdit{
+		{Line: 1, StartColumn: 3, EndColumn: 3, Replacement: " "},
+		{Line: 1, StartColumn: 3, EndColumn: 3, Replacement: " "},
+		{Line: 1, StartColumn: 3, EndColumn: 3, Replacement: "_"},
+		{Line: 2, StartColumn: 3, EndColumn: 4, Replacement: "，"},
+		{Line: 2, StartColumn: 3, EndColumn: 4, Replacement: "，"},
+		{Line: 2, StartColumn: 3, EndColumn: 5, Replacement: "，世"},
+		{Line: 9, StartColumn: 1, EndColumn: 1, Replacement: "x"},
+	})
+	if out != "使用 Go\r\n你好，世界\r\n" {
+		t.Fatalf("unexpected edited text: %q", out)
+	}
+	// 完全相同的编辑（全局与章节规则各报一次）都算已生效；同位置不同替换、区间重叠的仍被跳过
+	if !applied[0] || !applied[1] || applied[2] || !applied[3] || !applied[4] || applied[5] || applied[6] {
+		t.Fatalf("unexpected applied flags: %v", applied)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	b, err := Encode("中文", "gbk")
+	if err != nil {
+		t.Fatalf("encode gbk failed: %v", err)
+	}
+	want, _ := simplifiedchinese.GBK.NewEncoder().Bytes([]byte("中文"))
+	if string(b) != string(want) {
+		t.Fatalf("unexpected gbk bytes: %v", b)
+	}
+	if _, err := Encode("x", "latin1"); err == nil {
+		t.Fatalf("expected unsupported encoding error")
+	}
+}
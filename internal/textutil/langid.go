@@ -0,0 +1,245 @@
+package textutil
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+type LanguageScore struct {
+	Lang  string  `json:"lang"`
+	Score float64 `json:"score"`
+}
+
+// alphabetGroup 是共用同一字母表的一组语言：先按常用词和特征字母投票，
+// 再把该字母表的全部单词按票数比例分给组内语言。
+type alphabetGroup struct {
+	Stopwords map[string][]string
+	Markers   map[rune]string
+	// Fallback 是没有任何投票时的归属；FallbackASCII 为 true 时只在全部单词为 ASCII 时生效
+	Fallback      string
+	FallbackASCII bool
+
+	once  sync.Once
+	index map[string][]string
+}
+
+var latinGroup = alphabetGroup{
+	Stopwords: map[string][]string{
+		"en": strings.Fields("the and of to in is that it for was with as on be are this by not or have from you at which but an they we can will"),
+		"de": strings.Fields("der die und das ist nicht ein eine zu den von mit sich des auf für im dem auch es an werden aus er hat dass sie nach wird bei oder wir ich"),
+		"fr": strings.Fields("le la les de des et est un une du en que qui dans pour pas sur au avec il elle sont ce se plus par nous vous ne mais ou"),
+		"es": strings.Fields("el la los las de y que en un una es por con para del se no al lo como más pero sus su está son también muy hay este esta"),
+		"it": strings.Fields("il lo la i gli le di e che è un una per in con del della non sono si da al come anche più ma questo nel alla dei"),
+		"pt": strings.Fields("o a os as de e que em um uma é do da dos das para com não no na por se mais como mas ao ele ela são também você"),
+		"nl": strings.Fields("de het een en van is dat niet te in op zijn met voor er maar ook als aan bij door om dit wordt naar hij ze worden heeft wij"),
+	},
+	Markers: map[rune]string{
+		'ß': "de", 'ä': "de", 'ö': "de", 'ü': "de",
+		'ñ': "es",
+		'ã': "pt", 'õ': "pt",
+		'œ': "fr", 'ê': "fr", 'î': "fr", 'û': "fr", 'ë': "fr", 'ï': "fr",
+		'ì': "it", 'ò': "it",
+	},
+	Fallback:      "en",
+	FallbackASCII: true,
+}
+
+var cyrillicGroup = alphabetGroup{
+	Stopwords: map[string][]string{
+		"ru": strings.Fields("и в не на что с по это как он я к из то за для от так но все она был они"),
+		"uk": strings.Fields("і в не на що з по це як він я до із та за для від але ми всі вона був вони"),
+	},
+	Markers: map[rune]string{
+		'і': "uk", 'ї': "uk", 'є': "uk", 'ґ': "uk",
+		'ы': "ru", 'э': "ru", 'ё': "ru", 'ъ': "ru",
+	},
+	Fallback: "ru",
+}
+
+// DetectLanguages 离线识别文本语言，返回得分最高的 n 个候选（得分为 0~1 的证据占比）。
+// 中日韩按字计数（有假名判为 ja，有谚文判为 ko，其余汉字判为 zh）；
+// 字母文字按词计数，拉丁/西里尔字母再按常用词与特征字母区分具体语言。
+func DetectLanguages(s string, n int) []LanguageScore {
+	units := map[string]float64{}
+	total := 0.0
+
+	han, kana, hangul := 0, 0, 0
+	groupWords := map[*alphabetGroup][]string{}
+	var word strings.Builder
+	var wordGroup *alphabetGroup
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		w := strings.ToLower(word.String())
+		word.Reset()
+		total++
+		if wordGroup != nil {
+			groupWords[wordGroup] = append(groupWords[wordGroup], w)
+			return
+		}
+		if lang := scriptLanguage(w); lang != "" {
+			units[lang]++
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flush()
+			han++
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana) && r != 'ー':
+			flush()
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			flush()
+			hangul++
+		case unicode.IsLetter(r) || unicode.Is(unicode.Mn, r) || (r == '\'' && word.Len() > 0):
+			g := groupOf(r)
+			if unicode.Is(unicode.Mn, r) && word.Len() > 0 {
+				// 组合附加符号跟随前一个字母
+				g = wordGroup
+			}
+			if word.Len() > 0 && g != wordGroup {
+				flush()
+			}
+			wordGroup = g
+			word.WriteRune(r)
+		default:
+			flush()
+		}
+	}
+	flush()
+
+	total += float64(han + kana + hangul)
+	units["ja"] += float64(kana)
+	units["ko"] += float64(hangul)
+	switch {
+	case han == 0:
+	case kana*10 >= han+kana && kana > 0:
+		units["ja"] += float64(han)
+	case hangul > han:
+		units["ko"] += float64(han)
+	default:
+		units["zh"] += float64(han)
+	}
+
+	for g, words := range groupWords {
+		for lang, v := range g.attribute(words) {
+			units[lang] += v
+		}
+	}
+
+	out := make([]LanguageScore, 0, len(units))
+	if total <= 0 {
+		return out
+	}
+	for lang, v := range units {
+		if v <= 0 {
+			continue
+		}
+		out = append(out, LanguageScore{Lang: lang, Score: math.Round(v/total*10000) / 10000})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Lang < out[j].Lang
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+func (g *alphabetGroup) attribute(words []string) map[string]float64 {
+	votes := map[string]float64{}
+	totalVotes := 0.0
+	stop := g.stopIndex()
+	realWords := 0
+	allASCII := true
+	for _, w := range words {
+		marked := map[string]struct{}{}
+		for _, r := range w {
+			if r > unicode.MaxASCII {
+				allASCII = false
+			}
+			if lang, ok := g.Markers[r]; ok {
+				marked[lang] = struct{}{}
+			}
+		}
+		for lang := range marked {
+			votes[lang]++
+			totalVotes++
+		}
+		realWords++
+		for _, lang := range stop[w] {
+			votes[lang]++
+			totalVotes++
+		}
+	}
+
+	out := map[string]float64{}
+	if realWords == 0 {
+		return out
+	}
+	if totalVotes == 0 {
+		if !g.FallbackASCII || allASCII {
+			out[g.Fallback] = float64(realWords)
+		}
+		return out
+	}
+	for lang, v := range votes {
+		out[lang] = float64(realWords) * v / totalVotes
+	}
+	return out
+}
+
+// stopIndex 把 Stopwords 反转为 词 → 语言列表（同一个词可能属于多种语言）。
+func (g *alphabetGroup) stopIndex() map[string][]string {
+	g.once.Do(func() {
+		g.index = map[string][]string{}
+		for lang, list := range g.Stopwords {
+			for _, w := range list {
+				g.index[w] = append(g.index[w], lang)
+			}
+		}
+	})
+	return g.index
+}
+
+func groupOf(r rune) *alphabetGroup {
+	switch {
+	case unicode.Is(unicode.Latin, r):
+		return &latinGroup
+	case unicode.Is(unicode.Cyrillic, r):
+		return &cyrillicGroup
+	default:
+		return nil
+	}
+}
+
+// scriptLanguage 为只对应单一语言的文字返回语言代码。
+func scriptLanguage(w string) string {
+	for _, r := range w {
+		switch {
+		case unicode.Is(unicode.Greek, r):
+			return "el"
+		case unicode.Is(unicode.Arabic, r):
+			return "ar"
+		case unicode.Is(unicode.Hebrew, r):
+			return "he"
+		case unicode.Is(unicode.Thai, r):
+			return "th"
+		case unicode.Is(unicode.Devanagari, r):
+			return "hi"
+		}
+	}
+	return ""
+}
+
+// SupportedLanguages 是 DetectLanguages 可能给出的全部语言代码。
+var SupportedLanguages = []string{"zh", "ja", "ko", "en", "de", "fr", "es", "it", "pt", "nl", "ru", "uk", "el", "ar", "he", "th", "hi"}
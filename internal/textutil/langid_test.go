@@ -0,0 +1,40 @@
+package textutil
+
+import "testing"
+
+func TestDetectLanguages(t *testing.T) {
+	cases := map[string]string{
+		"这是一个中文文档，包含 API 和 HTTP 等词。":                                   "zh",
+		"日本語のテキストです。これはテストです。":                                         "ja",
+		"한국어 텍스트입니다. 이것은 테스트입니다.":                                      "ko",
+		"The quick brown fox jumps over the lazy dog and it was fine.": "en",
+		"Der Hund ist nicht in dem Haus und die Katze auch nicht.":     "de",
+		"Le chat est dans la maison et le chien est avec elle.":        "fr",
+		"El perro está en la casa y el gato también.":                  "es",
+		"Il gatto è nella casa e il cane non è con lui.":               "it",
+		"O cão está em casa e o gato também não.":                      "pt",
+		"De hond is in het huis en de kat is er ook niet.":             "nl",
+		"Это тестовый текст, и он не очень длинный.":                   "ru",
+		"Це тестовий текст, і він не дуже довгий.":                     "uk",
+	}
+	for text, want := range cases {
+		got := DetectLanguages(text, 3)
+		if len(got) == 0 || got[0].Lang != want {
+			t.Fatalf("%q: expected %s, got %+v", text, want, got)
+		}
+		if len(got) > 3 {
+			t.Fatalf("expected at most 3 candidates: %+v", got)
+		}
+	}
+
+	got := DetectLanguages("这是中文说明 with some English words", 0)
+	if len(got) != 2 || got[0].Lang != "zh" || got[1].Lang != "en" || got[0].Score+got[1].Score != 1 {
+		t.Fatalf("unexpected mixed candidates: %+v", got)
+	}
+	if len(DetectLanguages("12345 !!!", 3)) != 0 {
+		t.Fatalf("expected no candidates without letters")
+	}
+	if GuessLanguage("こんにちは") != "ja" || GuessLanguage("안녕하세요") != "ko" {
+		t.Fatalf("expected kana/hangul to be recognised")
+	}
+}
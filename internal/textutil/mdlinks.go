@@ -0,0 +1,449 @@
+package textutil
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	LinkInline     = "inline"
+	LinkReference  = "reference"
+	LinkShortcut   = "shortcut"
+	LinkDefinition = "definition"
+	LinkAutolink   = "autolink"
+)
+
+// MarkdownLink 是文档中的一处链接或图片。Line 从 1 开始，Column/EndColumn 为 rune 列号，
+// [Column, EndColumn) 覆盖整个链接语法（含 ! 与括号）。
+// Dest 为链接目标（reference/shortcut 需通过 Label 查定义），Text 为方括号里的文本。
+type MarkdownLink struct {
+	Kind      string
+	Image     bool
+	Text      string
+	Dest      string
+	Label     string
+	Line      int
+	Column    int
+	EndColumn int
+	// DestColumn 是目标地址在行内的起始列（inline/definition/autolink），0 表示没有。
+	DestColumn int
+}
+
+var (
+	linkDefRegex  = regexp.MustCompile(`^( {0,3})\[((?:[^\]\\]|\\.)+)\]:[ \t]*(<[^>]*>|\S+)(?:[ \t]+(?:"[^"]*"|'[^']*'|\([^)]*\)))?[ \t]*$`)
+	autolinkRegex = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.\-]{1,31}:[^\s<>]*|[A-Za-z0-9.!#$%&'*+/=?^_` + "`" + `{|}~\-]+@[A-Za-z0-9](?:[A-Za-z0-9\-]*[A-Za-z0-9])?(?:\.[A-Za-z0-9](?:[A-Za-z0-9\-]*[A-Za-z0-9])?)*)>`)
+	linkFenceOpen = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	labelSpace    = regexp.MustCompile(`\s+`)
+)
+
+// ExtractMarkdownLinks 按行提取 Markdown 链接、图片、引用定义与自动链接，
+// 跳过围栏代码块、缩进代码块和行内代码。链接文本不跨行。
+func ExtractMarkdownLinks(lines []string) []MarkdownLink {
+	out := make([]MarkdownLink, 0)
+	for i, prose := range markdownProseLines(lines) {
+		if !prose {
+			continue
+		}
+		ln := lines[i]
+		if m := linkDefRegex.FindStringSubmatchIndex(ln); m != nil {
+			dest := ln[m[6]:m[7]]
+			destCol := len([]rune(ln[:m[6]])) + 1
+			if strings.HasPrefix(dest, "<") {
+				dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+				destCol++
+			}
+			out = append(out, MarkdownLink{
+				Kind:       LinkDefinition,
+				Label:      ln[m[4]:m[5]],
+				Dest:       dest,
+				Line:       i + 1,
+				Column:     len([]rune(ln[:m[3]])) + 1,
+				EndColumn:  len([]rune(strings.TrimRight(ln, " \t"))) + 1,
+				DestColumn: destCol,
+			})
+			continue
+		}
+		runes := maskCodeSpans([]rune(ln))
+		out = append(out, scanLinks(runes, 0, len(runes), i+1)...)
+	}
+	return out
+}
+
+// markdownProseLines 标记每行是否为正文：围栏代码块（含围栏行）与缩进代码块为 false。
+func markdownProseLines(lines []string) []bool {
+	out := make([]bool, len(lines))
+	fence := ""
+	prevBlank := true
+	for i, ln := range lines {
+		if fence != "" {
+			if t := strings.TrimSpace(ln); strings.HasPrefix(t, fence) && strings.Trim(t, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if m := linkFenceOpen.FindStringSubmatch(ln); m != nil {
+			fence = m[1]
+			continue
+		}
+		blank := strings.TrimSpace(ln) == ""
+		if prevBlank && !blank && indentWidth(ln) >= 4 {
+			continue
+		}
+		prevBlank = blank
+		out[i] = !blank
+	}
+	return out
+}
+
+// MaskMarkdownCode 返回与 lines 一一对应、列号不变的副本：代码块行置空，行内代码替换为空格，
+// 供只检查正文的规则使用。
+func MaskMarkdownCode(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, prose := range markdownProseLines(lines) {
+		if prose {
+			out[i] = string(maskCodeSpans([]rune(lines[i])))
+		}
+	}
+	return out
+}
+
+// BareURL 是正文里没有写成链接的 URL，[Column, EndColumn) 为 rune 列号范围。
+type BareURL struct {
+	URL       string
+	Line      int
+	Column    int
+	EndColumn int
+}
+
+var (
+	bareURLRegex  = regexp.MustCompile(`(?i)\b(?:https?|ftp)://[A-Za-z0-9\-._~:/?#@!$&()*+,;=%]+|\bwww\.[A-Za-z0-9\-]+\.[A-Za-z0-9\-._~:/?#@!$&()*+,;=%]+`)
+	htmlTagMaskRe = regexp.MustCompile(`<[^>]*>`)
+)
+
+const urlTrailingSet = ".,;:!?'\"*_~）。，；：！？、」』"
+
+// FindBareURLs 找出正文中的裸 URL：跳过代码、链接/图片/自动链接、引用定义与 HTML 标签内部，
+// 结尾的标点和不成对的右括号不计入 URL。
+func FindBareURLs(lines []string) []BareURL {
+	out := make([]BareURL, 0)
+	for i, prose := range markdownProseLines(lines) {
+		if !prose || linkDefRegex.MatchString(lines[i]) {
+			continue
+		}
+		runes := maskCodeSpans([]rune(lines[i]))
+		for _, l := range scanLinks(runes, 0, len(runes), i+1) {
+			if l.Kind == LinkShortcut {
+				continue
+			}
+			for k := l.Column - 1; k < l.EndColumn-1 && k < len(runes); k++ {
+				runes[k] = ' '
+			}
+		}
+		masked := string(runes)
+		masked = htmlTagMaskRe.ReplaceAllStringFunc(masked, func(tag string) string {
+			return strings.Repeat(" ", utf8.RuneCountInString(tag))
+		})
+		for _, m := range bareURLRegex.FindAllStringIndex(masked, -1) {
+			u := trimURLTrailing(masked[m[0]:m[1]])
+			col := len([]rune(masked[:m[0]])) + 1
+			out = append(out, BareURL{URL: u, Line: i + 1, Column: col, EndColumn: col + len([]rune(u))})
+		}
+	}
+	return out
+}
+
+func trimURLTrailing(u string) string {
+	for u != "" {
+		r := []rune(u)
+		last := r[len(r)-1]
+		if strings.ContainsRune(urlTrailingSet, last) {
+			u = string(r[:len(r)-1])
+			continue
+		}
+		if last == ')' && strings.Count(u, "(") < strings.Count(u, ")") {
+			u = string(r[:len(r)-1])
+			continue
+		}
+		break
+	}
+	return u
+}
+
+// HTMLImage 是正文中的 <img> 标签；HasAlt 表示写了 alt 属性，Alt 为其值。
+type HTMLImage struct {
+	Line   int
+	Column int
+	HasAlt bool
+	Alt    string
+}
+
+var (
+	htmlImgRegex = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	htmlAltRegex = regexp.MustCompile(`(?i)\salt\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>"']+))`)
+)
+
+// ExtractHTMLImages 提取正文中的 <img> 标签（跳过代码块与行内代码）。
+func ExtractHTMLImages(lines []string) []HTMLImage {
+	out := make([]HTMLImage, 0)
+	for i, prose := range markdownProseLines(lines) {
+		if !prose {
+			continue
+		}
+		masked := string(maskCodeSpans([]rune(lines[i])))
+		for _, m := range htmlImgRegex.FindAllStringIndex(masked, -1) {
+			img := HTMLImage{Line: i + 1, Column: len([]rune(masked[:m[0]])) + 1}
+			if a := htmlAltRegex.FindStringSubmatch(masked[m[0]:m[1]]); a != nil {
+				img.HasAlt = true
+				img.Alt = a[1] + a[2] + a[3]
+			}
+			out = append(out, img)
+		}
+	}
+	return out
+}
+
+// NormalizeLinkLabel 按 CommonMark 规则归一化引用标签：折叠空白并忽略大小写。
+func NormalizeLinkLabel(s string) string {
+	return strings.ToLower(labelSpace.ReplaceAllString(strings.TrimSpace(s), " "))
+}
+
+func scanLinks(r []rune, from, to, line int) []MarkdownLink {
+	out := make([]MarkdownLink, 0)
+	for i := from; i < to; i++ {
+		switch r[i] {
+		case '\\':
+			i++
+		case '<':
+			if m := autolinkRegex.FindStringSubmatch(string(r[i:to])); m != nil {
+				n := len([]rune(m[0]))
+				out = append(out, MarkdownLink{Kind: LinkAutolink, Dest: m[1], Text: m[1], Line: line, Column: i + 1, EndColumn: i + n + 1, DestColumn: i + 2})
+				i += n - 1
+			}
+		case '[':
+			image := i > from && r[i-1] == '!' && !(i-1 > from && r[i-2] == '\\')
+			start := i
+			if image {
+				start = i - 1
+			}
+			closeIdx := matchBracket(r, i, to)
+			if closeIdx < 0 {
+				continue
+			}
+			text := string(r[i+1 : closeIdx])
+			next := closeIdx + 1
+			if next < to && r[next] == '(' {
+				if dest, destStart, end, ok := parseInlineDest(r, next, to); ok {
+					out = append(out, MarkdownLink{Kind: LinkInline, Image: image, Text: text, Dest: dest, Line: line, Column: start + 1, EndColumn: end + 1, DestColumn: destStart + 1})
+					out = append(out, scanLinks(r, i+1, closeIdx, line)...)
+					i = end - 1
+					continue
+				}
+			}
+			if next < to && r[next] == '[' {
+				if lc := matchBracket(r, next, to); lc >= 0 {
+					label := string(r[next+1 : lc])
+					if strings.TrimSpace(label) == "" {
+						label = text
+					}
+					out = append(out, MarkdownLink{Kind: LinkReference, Image: image, Text: text, Label: label, Line: line, Column: start + 1, EndColumn: lc + 2})
+					out = append(out, scanLinks(r, i+1, closeIdx, line)...)
+					i = lc
+					continue
+				}
+			}
+			if strings.TrimSpace(text) != "" {
+				out = append(out, MarkdownLink{Kind: LinkShortcut, Image: image, Text: text, Label: text, Line: line, Column: start + 1, EndColumn: closeIdx + 2})
+			}
+		}
+	}
+	return out
+}
+
+// matchBracket 返回与 r[open] 处 '[' 配对的 ']' 下标，考虑嵌套与转义；找不到返回 -1。
+func matchBracket(r []rune, open, to int) int {
+	depth := 0
+	for i := open; i < to; i++ {
+		switch r[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseInlineDest 解析 r[open] 处 '(' 开始的 (dest "title")，返回目标、目标起始下标与 ')' 之后的下标。
+func parseInlineDest(r []rune, open, to int) (string, int, int, bool) {
+	i := open + 1
+	for i < to && (r[i] == ' ' || r[i] == '\t') {
+		i++
+	}
+	destStart := i
+	var dest string
+	if i < to && r[i] == '<' {
+		j := i + 1
+		for j < to && r[j] != '>' {
+			j++
+		}
+		if j >= to {
+			return "", 0, 0, false
+		}
+		dest = string(r[i+1 : j])
+		destStart = i + 1
+		i = j + 1
+	} else {
+		depth := 0
+		j := i
+		for ; j < to; j++ {
+			c := r[j]
+			if c == '\\' {
+				j++
+				continue
+			}
+			if c == ' ' || c == '\t' {
+				break
+			}
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+		}
+		if j > to {
+			j = to
+		}
+		dest = string(r[i:j])
+		i = j
+	}
+	for i < to && (r[i] == ' ' || r[i] == '\t') {
+		i++
+	}
+	if i < to && (r[i] == '"' || r[i] == '\'' || r[i] == '(') {
+		closer := r[i]
+		if closer == '(' {
+			closer = ')'
+		}
+		j := i + 1
+		for j < to && r[j] != closer {
+			if r[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= to {
+			return "", 0, 0, false
+		}
+		i = j + 1
+		for i < to && (r[i] == ' ' || r[i] == '\t') {
+			i++
+		}
+	}
+	if i >= to || r[i] != ')' {
+		return "", 0, 0, false
+	}
+	return dest, destStart, i + 1, true
+}
+
+// maskCodeSpans 把行内代码（`code`）的内容替换为空格，保持列号不变。
+func maskCodeSpans(r []rune) []rune {
+	out := append([]rune(nil), r...)
+	for i := 0; i < len(out); i++ {
+		if out[i] != '`' {
+			continue
+		}
+		n := 0
+		for i+n < len(out) && out[i+n] == '`' {
+			n++
+		}
+		closeAt := -1
+		for j := i + n; j < len(out); j++ {
+			if out[j] != '`' {
+				continue
+			}
+			m := 0
+			for j+m < len(out) && out[j+m] == '`' {
+				m++
+			}
+			if m == n {
+				closeAt = j
+				break
+			}
+			j += m - 1
+		}
+		if closeAt < 0 {
+			i += n - 1
+			continue
+		}
+		for k := i; k < closeAt+n; k++ {
+			out[k] = ' '
+		}
+		i = closeAt + n - 1
+	}
+	return out
+}
+
+func indentWidth(ln string) int {
+	col := 0
+	for _, c := range ln {
+		switch c {
+		case ' ':
+			col++
+		case '\t':
+			col += 4 - col%4
+		default:
+			return col
+		}
+	}
+	return col
+}
+
+var (
+	slugInlineLink = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	slugHTMLTag    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// HeadingSlugs 按 GitHub 规则为标题生成锚点：小写，去掉除字母、数字、空格、- 和 _ 以外的字符，
+// 空格换成 -；重名依次追加 -1、-2……。
+func HeadingSlugs(titles []string) []string {
+	seen := map[string]int{}
+	out := make([]string, 0, len(titles))
+	for _, t := range titles {
+		base := headingSlug(t)
+		slug := base
+		if n, ok := seen[base]; ok {
+			slug = base + "-" + strconv.Itoa(n)
+			seen[base] = n + 1
+		} else {
+			seen[base] = 1
+		}
+		out = append(out, slug)
+	}
+	return out
+}
+
+func headingSlug(title string) string {
+	s := slugInlineLink.ReplaceAllString(title, "$1")
+	s = slugHTMLTag.ReplaceAllString(s, "")
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
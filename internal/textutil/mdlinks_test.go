@@ -0,0 +1,101 @@
+package textutil
+
+import "testing"
+
+func TestExtractMarkdownLinks(t *testing.T) {
+	lines := []string{
+		"见 [指南](docs/guide.md#安装 \"标题\") 和 ![图](<img/a b.png>)。",
+		"[![徽章](badge.svg)](https://example.com/x_(y)) `[代码](no.md)` \\[转义](no.md)",
+		"[全称][Ref] [折叠][] [简写] <https://a.b/c> <me@example.com>",
+		"",
+		"```",
+		"[围栏](no.md)",
+		"```",
+		"",
+		"    [缩进](no.md)",
+		"",
+		"  [ref]: <other file.md> \"t\"",
+	}
+	links := ExtractMarkdownLinks(lines)
+	type want struct {
+		kind  string
+		image bool
+		dest  string
+		label string
+		line  int
+		col   int
+	}
+	expected := []want{
+		{LinkInline, false, "docs/guide.md#安装", "", 1, 3},
+		{LinkInline, true, "img/a b.png", "", 1, 33},
+		{LinkInline, false, "https://example.com/x_(y)", "", 2, 1},
+		{LinkInline, true, "badge.svg", "", 2, 2},
+		{LinkReference, false, "", "Ref", 3, 1},
+		{LinkReference, false, "", "折叠", 3, 11},
+		{LinkShortcut, false, "", "简写", 3, 18},
+		{LinkAutolink, false, "https://a.b/c", "", 3, 23},
+		{LinkAutolink, false, "me@example.com", "", 3, 39},
+		{LinkDefinition, false, "other file.md", "ref", 11, 3},
+	}
+	if len(links) != len(expected) {
+		t.Fatalf("unexpected links: %+v", links)
+	}
+	for i, w := range expected {
+		l := links[i]
+		if l.Kind != w.kind || l.Image != w.image || l.Dest != w.dest || l.Label != w.label || l.Line != w.line || l.Column != w.col {
+			t.Fatalf("link %d: want %+v, got %+v", i, w, l)
+		}
+	}
+	if links[0].EndColumn != 30 || links[0].Text != "指南" {
+		t.Fatalf("unexpected range/text: %+v", links[0])
+	}
+	if NormalizeLinkLabel("  Foo \t Bar ") != "foo bar" {
+		t.Fatalf("label normalization failed")
+	}
+}
+
+func TestHeadingSlugs(t *testing.T) {
+	got := HeadingSlugs([]string{"Hello, World!", "安装 指南", "Hello World", "Hello World", "[链接](x.md) `code`"})
+	want := []string{"hello-world", "安装-指南", "hello-world-1", "hello-world-2", "链接-code"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("slug %d: want %q got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestFindBareURLsAndHTMLImages(t *testing.T) {
+	lines := []string{
+		"见 https://a.example/x(1)). 与 [链接](https://b.example) <https://c.example>",
+		"<a href=\"https://d.example\">d</a> `https://e.example` 和 www.f.example/路径",
+		"```",
+		"https://g.example",
+		"```",
+		"<img src=\"a.png\"> <IMG alt='logo' src=b.png> <img alt=\"\">",
+	}
+	urls := FindBareURLs(lines)
+	if len(urls) != 2 {
+		t.Fatalf("unexpected urls: %+v", urls)
+	}
+	if urls[0].URL != "https://a.example/x(1)" || urls[0].Line != 1 || urls[0].Column != 3 || urls[0].EndColumn != 25 {
+		t.Fatalf("unexpected first url: %+v", urls[0])
+	}
+	if urls[1].URL != "www.f.example/" || urls[1].Line != 2 {
+		t.Fatalf("unexpected second url: %+v", urls[1])
+	}
+
+	imgs := ExtractHTMLImages(lines)
+	if len(imgs) != 3 || imgs[0].HasAlt || !imgs[1].HasAlt || imgs[1].Alt != "logo" || !imgs[2].HasAlt || imgs[2].Alt != "" || imgs[1].Column != 19 {
+		t.Fatalf("unexpected images: %+v", imgs)
+	}
+}
+
+func TestMaskMarkdownCode(t *testing.T) {
+	got := MaskMarkdownCode([]string{"正文 `代码` 正文", "```", "code", "```", "", "    indented"})
+	want := []string{"正文      正文", "", "", "", "", ""}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("line %d: want %q, got %q", i, want[i], got[i])
+		}
+	}
+}
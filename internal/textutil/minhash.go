@@ -0,0 +1,120 @@
+package textutil
+
+import (
+	"unicode"
+)
+
+const (
+	// MinHashSize 是签名长度（哈希函数个数），估算 Jaccard 相似度的标准误约为 1/sqrt(128) ≈ 0.09。
+	MinHashSize = 128
+	// ShingleSize 是按字符切分的 shingle 长度。按字符而不是按词切分，中英文都适用。
+	ShingleSize = 5
+)
+
+// MinHash 是文本 shingle 集合的 MinHash 签名，两份签名中相同位置相等的比例即 Jaccard 相似度的估计。
+type MinHash [MinHashSize]uint64
+
+// ComputeMinHash 对规范化后的文本（小写、连续空白合并为一个空格）按 ShingleSize 个字符切分并计算签名。
+// 文本为空时返回 false；短于 ShingleSize 的文本整体作为一个 shingle。
+func ComputeMinHash(text string) (MinHash, bool) {
+	var m MinHash
+	runes := normalizeForShingles(text)
+	if len(runes) == 0 {
+		return m, false
+	}
+	for i := range m {
+		m[i] = ^uint64(0)
+	}
+	k := ShingleSize
+	if len(runes) < k {
+		k = len(runes)
+	}
+	for start := 0; start+k <= len(runes); start++ {
+		h := shingleHash(runes[start : start+k])
+		for i := range m {
+			if v := mix64(h ^ minHashSeeds[i]); v < m[i] {
+				m[i] = v
+			}
+		}
+	}
+	return m, true
+}
+
+// Similarity 估算两份签名对应文本的 Jaccard 相似度（0~1）。
+func (m *MinHash) Similarity(o *MinHash) float64 {
+	same := 0
+	for i := range m {
+		if m[i] == o[i] {
+			same++
+		}
+	}
+	return float64(same) / MinHashSize
+}
+
+// Bands 把签名切成 n 段并对每段取哈希，供局部敏感哈希（LSH）找候选对：任一段相同的两份签名才需要比较。
+// n 须能整除 MinHashSize。
+func (m *MinHash) Bands(n int) []uint64 {
+	rows := MinHashSize / n
+	out := make([]uint64, n)
+	for b := 0; b < n; b++ {
+		h := uint64(fnvOffset64) ^ uint64(b)
+		for _, v := range m[b*rows : (b+1)*rows] {
+			h = mix64(h ^ v)
+		}
+		out[b] = h
+	}
+	return out
+}
+
+func normalizeForShingles(text string) []rune {
+	out := make([]rune, 0, len(text))
+	space := false
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			space = len(out) > 0
+			continue
+		}
+		if space {
+			out = append(out, ' ')
+			space = false
+		}
+		out = append(out, unicode.ToLower(r))
+	}
+	return out
+}
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+func shingleHash(rs []rune) uint64 {
+	h := uint64(fnvOffset64)
+	for _, r := range rs {
+		for s := 0; s < 32; s += 8 {
+			h ^= uint64(byte(r >> s))
+			h *= fnvPrime64
+		}
+	}
+	return h
+}
+
+// mix64 是 splitmix64 的混合函数，与不同种子异或后相当于一组独立的哈希函数。
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+var minHashSeeds = func() [MinHashSize]uint64 {
+	var seeds [MinHashSize]uint64
+	s := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		s += 0x9e3779b97f4a7c15
+		seeds[i] = mix64(s)
+	}
+	return seeds
+}()
@@ -0,0 +1,47 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComputeMinHashSimilarity(t *testing.T) {
+	base := strings.Repeat("The release notes describe every change shipped in version two of the product. ", 8)
+	a, ok := ComputeMinHash(base + "Published on 2024-01-01.")
+	if !ok {
+		t.Fatalf("expected signature")
+	}
+	b, _ := ComputeMinHash(base + "Published on 2024-03-15.")
+	c, _ := ComputeMinHash(strings.Repeat("完全不同的中文内容，用来验证相似度很低。", 10))
+	if s := a.Similarity(&a); s != 1 {
+		t.Fatalf("self similarity should be 1, got %v", s)
+	}
+	if s := a.Similarity(&b); s < 0.7 {
+		t.Fatalf("near-identical texts should be similar, got %v", s)
+	}
+	if s := a.Similarity(&c); s > 0.1 {
+		t.Fatalf("unrelated texts should not be similar, got %v", s)
+	}
+
+	x, _ := ComputeMinHash("Hello   World\n")
+	y, _ := ComputeMinHash("hello world")
+	if x.Similarity(&y) != 1 {
+		t.Fatalf("case and whitespace should be normalized")
+	}
+	if _, ok := ComputeMinHash(" \n\t"); ok {
+		t.Fatalf("blank text should have no signature")
+	}
+	if short, ok := ComputeMinHash("ab"); !ok || short[0] == ^uint64(0) {
+		t.Fatalf("short text should still be hashed")
+	}
+}
+
+func TestMinHashBands(t *testing.T) {
+	a, _ := ComputeMinHash("some shared text for banding")
+	b := a
+	b[0]++
+	ba, bb := a.Bands(32), b.Bands(32)
+	if len(ba) != 32 || ba[0] == bb[0] || ba[1] != bb[1] {
+		t.Fatalf("only the changed band should differ: %v %v", ba[:2], bb[:2])
+	}
+}
@@ -0,0 +1,138 @@
+package textutil
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	ReadabilityFlesch = "flesch"
+	ReadabilityZh     = "zh_sentence"
+)
+
+// Readability 是可读性评分。ReadingEase 越高越易读（0~100 左右），
+// GradeLevel 近似对应阅读所需的年级。
+type Readability struct {
+	Method      string  `json:"method"`
+	ReadingEase float64 `json:"reading_ease"`
+	GradeLevel  float64 `json:"grade_level"`
+	Sentences   int     `json:"sentences"`
+}
+
+// ComputeReadability 按语言选择算法：en 用 Flesch 阅读难易度与 Flesch–Kincaid 年级，
+// zh 用基于句长与分句长度的指数。其他语言或没有句子时返回 false。
+func ComputeReadability(lines []string, lang string) (Readability, bool) {
+	sentences := SplitSentences(lines)
+	if len(sentences) == 0 {
+		return Readability{}, false
+	}
+	switch lang {
+	case "en":
+		return fleschReadability(sentences)
+	case "zh":
+		return zhReadability(sentences)
+	default:
+		return Readability{}, false
+	}
+}
+
+func fleschReadability(sentences []Sentence) (Readability, bool) {
+	words, syllables := 0, 0
+	for _, s := range sentences {
+		for _, w := range latinWords(s.Text) {
+			words++
+			syllables += EnglishSyllables(w)
+		}
+	}
+	if words == 0 {
+		return Readability{}, false
+	}
+	wps := float64(words) / float64(len(sentences))
+	spw := float64(syllables) / float64(words)
+	return Readability{
+		Method:      ReadabilityFlesch,
+		ReadingEase: round2(206.835 - 1.015*wps - 84.6*spw),
+		GradeLevel:  round2(math.Max(0, 0.39*wps+11.8*spw-15.59)),
+		Sentences:   len(sentences),
+	}, true
+}
+
+// zhReadability：年级 = 0.25×平均句长 + 0.35×平均分句长 − 2（按字计，分句以 ，；：、 切分），
+// 难易度 = 100 − 6×年级，截断到 0~100。
+func zhReadability(sentences []Sentence) (Readability, bool) {
+	chars, clauses := 0, 0
+	for _, s := range sentences {
+		clauseChars := 0
+		for _, r := range s.Text {
+			if isClauseBreak(r) || isCJKTerminator(r) {
+				if clauseChars > 0 {
+					clauses++
+				}
+				clauseChars = 0
+				continue
+			}
+			if unicode.IsSpace(r) || unicode.IsPunct(r) {
+				continue
+			}
+			chars++
+			clauseChars++
+		}
+		if clauseChars > 0 {
+			clauses++
+		}
+	}
+	if chars == 0 || clauses == 0 {
+		return Readability{}, false
+	}
+	avgSentence := float64(chars) / float64(len(sentences))
+	avgClause := float64(chars) / float64(clauses)
+	grade := math.Max(0, 0.25*avgSentence+0.35*avgClause-2)
+	ease := math.Min(100, math.Max(0, 100-6*grade))
+	return Readability{
+		Method:      ReadabilityZh,
+		ReadingEase: round2(ease),
+		GradeLevel:  round2(grade),
+		Sentences:   len(sentences),
+	}, true
+}
+
+// EnglishSyllables 按元音组估算英文单词音节数（去掉词尾不发音的 e），至少为 1。
+func EnglishSyllables(word string) int {
+	w := strings.ToLower(word)
+	w = strings.TrimSuffix(w, "'s")
+	n := 0
+	prevVowel := false
+	for _, r := range w {
+		v := strings.ContainsRune("aeiouy", r)
+		if v && !prevVowel {
+			n++
+		}
+		prevVowel = v
+	}
+	if strings.HasSuffix(w, "e") && !strings.HasSuffix(w, "le") && n > 1 {
+		n--
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+func latinWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !(unicode.Is(unicode.Latin, r) || r == '\'')
+	})
+}
+
+func isClauseBreak(r rune) bool {
+	switch r {
+	case '，', '；', '：', '、', ',', ';', ':':
+		return true
+	}
+	return false
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,39 @@
+package textutil
+
+import "testing"
+
+func TestComputeReadability(t *testing.T) {
+	easy, ok := ComputeReadability([]string{"The cat sat on the mat. It was happy."}, "en")
+	if !ok || easy.Method != ReadabilityFlesch || easy.Sentences != 2 {
+		t.Fatalf("unexpected english readability: %+v ok=%v", easy, ok)
+	}
+	hard, _ := ComputeReadability([]string{"The implementation of heterogeneous distributed computational infrastructure necessitates comprehensive architectural considerations."}, "en")
+	if hard.ReadingEase >= easy.ReadingEase || hard.GradeLevel <= easy.GradeLevel {
+		t.Fatalf("expected long words to be harder: easy=%+v hard=%+v", easy, hard)
+	}
+
+	short, ok := ComputeReadability([]string{"我们今天去公园。天气很好！"}, "zh")
+	if !ok || short.Method != ReadabilityZh || short.GradeLevel != 1.3 || short.ReadingEase != 92.2 {
+		t.Fatalf("unexpected chinese readability: %+v ok=%v", short, ok)
+	}
+	long, _ := ComputeReadability([]string{"在分布式系统的设计过程中，需要综合考虑数据一致性、可用性以及分区容错性之间的权衡关系，并根据具体业务场景选择合适的技术方案。"}, "zh")
+	if long.GradeLevel <= short.GradeLevel || long.ReadingEase != 0 {
+		t.Fatalf("expected long chinese sentence to be harder: %+v", long)
+	}
+
+	if _, ok := ComputeReadability([]string{"Der Hund ist hier."}, "de"); ok {
+		t.Fatalf("unsupported language should not be scored")
+	}
+	if _, ok := ComputeReadability([]string{"# only heading"}, "en"); ok {
+		t.Fatalf("text without sentences should not be scored")
+	}
+}
+
+func TestEnglishSyllables(t *testing.T) {
+	cases := map[string]int{"the": 1, "cat": 1, "happy": 2, "table": 2, "made": 1, "implementation": 5, "rhythm": 1}
+	for w, want := range cases {
+		if got := EnglishSyllables(w); got != want {
+			t.Fatalf("%s: expected %d syllables, got %d", w, want, got)
+		}
+	}
+}
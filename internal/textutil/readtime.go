@@ -0,0 +1,77 @@
+package textutil
+
+import (
+	"math"
+	"unicode"
+)
+
+// ReadingSpeed 阅读/朗读速度：字母文字按词/分钟，中日韩文字按字/分钟。
+type ReadingSpeed struct {
+	WordsPerMinute         int
+	CharsPerMinute         int
+	SpeakingWordsPerMinute int
+	SpeakingCharsPerMinute int
+}
+
+var DefaultReadingSpeed = ReadingSpeed{
+	WordsPerMinute:         230,
+	CharsPerMinute:         400,
+	SpeakingWordsPerMinute: 150,
+	SpeakingCharsPerMinute: 250,
+}
+
+// WithDefaults 用默认值补齐未设置（<=0）的速度。
+func (sp ReadingSpeed) WithDefaults() ReadingSpeed {
+	if sp.WordsPerMinute <= 0 {
+		sp.WordsPerMinute = DefaultReadingSpeed.WordsPerMinute
+	}
+	if sp.CharsPerMinute <= 0 {
+		sp.CharsPerMinute = DefaultReadingSpeed.CharsPerMinute
+	}
+	if sp.SpeakingWordsPerMinute <= 0 {
+		sp.SpeakingWordsPerMinute = DefaultReadingSpeed.SpeakingWordsPerMinute
+	}
+	if sp.SpeakingCharsPerMinute <= 0 {
+		sp.SpeakingCharsPerMinute = DefaultReadingSpeed.SpeakingCharsPerMinute
+	}
+	return sp
+}
+
+type ReadingTime struct {
+	ReadingSeconds  int
+	SpeakingSeconds int
+}
+
+// EstimateReadingTime 统计词数与中日韩字数，按速度估算阅读/朗读秒数（四舍五入，有内容时至少 1 秒）。
+func EstimateReadingTime(s string, sp ReadingSpeed) ReadingTime {
+	sp = sp.WithDefaults()
+	words, cjk := 0, 0
+	inWord := false
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			cjk++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		case r == '\'' || r == '-':
+			// 词内的撇号/连字符不断词
+		default:
+			inWord = false
+		}
+	}
+	seconds := func(wpm, cpm int) int {
+		if words == 0 && cjk == 0 {
+			return 0
+		}
+		v := float64(words)*60/float64(wpm) + float64(cjk)*60/float64(cpm)
+		return int(math.Max(1, math.Round(v)))
+	}
+	return ReadingTime{
+		ReadingSeconds:  seconds(sp.WordsPerMinute, sp.CharsPerMinute),
+		SpeakingSeconds: seconds(sp.SpeakingWordsPerMinute, sp.SpeakingCharsPerMinute),
+	}
+}
@@ -0,0 +1,18 @@
+package textutil
+
+import "testing"
+
+func TestEstimateReadingTime(t *testing.T) {
+	sp := ReadingSpeed{WordsPerMinute: 60, CharsPerMinute: 120, SpeakingWordsPerMinute: 30, SpeakingCharsPerMinute: 60}
+	// 6 个词 + 4 个汉字：阅读 6s + 2s，朗读 12s + 4s
+	rt := EstimateReadingTime("It's a well-known fact, 2024 edition. 中文内容", sp)
+	if rt.ReadingSeconds != 8 || rt.SpeakingSeconds != 16 {
+		t.Fatalf("unexpected reading time: %+v", rt)
+	}
+	if rt := EstimateReadingTime("  \n", sp); rt.ReadingSeconds != 0 {
+		t.Fatalf("empty text should take no time: %+v", rt)
+	}
+	if rt := EstimateReadingTime("hi", ReadingSpeed{}); rt.ReadingSeconds != 1 || rt.SpeakingSeconds != 1 {
+		t.Fatalf("short text should take at least one second with defaults: %+v", rt)
+	}
+}
@@ -0,0 +1,269 @@
+package textutil
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Paragraph 是以空行分隔的一段文本。行号从 1 开始，相对于传入的行切片。
+type Paragraph struct {
+	StartLine int
+	EndLine   int
+	Lines     int
+	Chars     int
+	Text      string
+}
+
+// Sentence 是段落内的一个句子，Line/Column 指向句子第一个非空白字符。
+// Text 中的段内换行已按语境合并（中文之间直接相连，其余替换为空格）。
+type Sentence struct {
+	Line   int
+	Column int
+	Chars  int
+	Text   string
+}
+
+type SegmentStats struct {
+	Sentences         int
+	Paragraphs        int
+	AvgSentenceLength float64
+	MaxSentenceLength int
+}
+
+var (
+	atxHeadingRegex = regexp.MustCompile(`^\s{0,3}#{1,6}(\s|$)`)
+	fenceRegex      = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+)
+
+// 句末缩写（小写、不含末尾的点），这些词后面的 "." 不视为句子结束。
+var sentenceAbbreviations = map[string]struct{}{
+	"mr": {}, "mrs": {}, "ms": {}, "dr": {}, "prof": {}, "sr": {}, "jr": {}, "st": {},
+	"vs": {}, "etc": {}, "e.g": {}, "i.e": {}, "cf": {}, "al": {}, "fig": {},
+	"vol": {}, "inc": {}, "ltd": {}, "co": {}, "corp": {}, "approx": {}, "dept": {},
+	"a.m": {}, "p.m": {}, "u.s": {}, "u.k": {}, "jan": {}, "feb": {}, "mar": {}, "apr": {},
+	"jun": {}, "jul": {}, "aug": {}, "sep": {}, "sept": {}, "oct": {}, "nov": {}, "dec": {},
+}
+
+// SplitParagraphs 按空行切分段落；Markdown 标题行只作分隔，围栏代码块整体跳过。
+func SplitParagraphs(lines []string) []Paragraph {
+	paras := make([]Paragraph, 0)
+	start := -1
+	inFence := ""
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		p := Paragraph{StartLine: start + 1, EndLine: end, Lines: end - start}
+		p.Text = strings.Join(lines[start:end], "\n")
+		for _, ln := range lines[start:end] {
+			p.Chars += utf8.RuneCountInString(ln)
+		}
+		paras = append(paras, p)
+		start = -1
+	}
+	for i, ln := range lines {
+		if m := fenceRegex.FindStringSubmatch(ln); m != nil {
+			switch inFence {
+			case "":
+				flush(i)
+				inFence = m[1]
+				continue
+			case m[1]:
+				inFence = ""
+				continue
+			}
+		}
+		if inFence != "" {
+			continue
+		}
+		if strings.TrimSpace(ln) == "" {
+			flush(i)
+			continue
+		}
+		if atxHeadingRegex.MatchString(ln) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if inFence == "" {
+		flush(len(lines))
+	}
+	return paras
+}
+
+// SplitSentences 在段落内按 。！？ 与 .!? 切分句子；英文缩写、首字母缩写、小数和
+// 后接小写字母的 "." 不断句，句末的引号/括号归入前一句。
+func SplitSentences(lines []string) []Sentence {
+	sentences := make([]Sentence, 0)
+	for _, p := range SplitParagraphs(lines) {
+		sentences = append(sentences, splitParagraphSentences(lines[p.StartLine-1:p.EndLine], p.StartLine)...)
+	}
+	return sentences
+}
+
+type posRune struct {
+	r    rune
+	line int
+	col  int
+}
+
+func splitParagraphSentences(lines []string, firstLine int) []Sentence {
+	// 把段落展开成带位置的 rune 序列，行与行之间插入一个换行占位
+	rs := make([]posRune, 0)
+	for i, ln := range lines {
+		if i > 0 {
+			rs = append(rs, posRune{r: '\n', line: firstLine + i - 1})
+		}
+		col := 0
+		for _, r := range ln {
+			col++
+			rs = append(rs, posRune{r: r, line: firstLine + i, col: col})
+		}
+	}
+
+	out := make([]Sentence, 0)
+	start := -1
+	emit := func(end int) {
+		if start < 0 {
+			return
+		}
+		text := joinSentenceRunes(rs[start:end])
+		if text != "" {
+			out = append(out, Sentence{Line: rs[start].line, Column: rs[start].col, Chars: utf8.RuneCountInString(text), Text: text})
+		}
+		start = -1
+	}
+	for i := 0; i < len(rs); i++ {
+		r := rs[i].r
+		if start < 0 {
+			if unicode.IsSpace(r) {
+				continue
+			}
+			start = i
+		}
+		end := -1
+		switch {
+		case isCJKTerminator(r):
+			end = i + 1
+		case r == '.' || r == '!' || r == '?':
+			if isLatinSentenceEnd(rs, start, i) {
+				end = i + 1
+			}
+		}
+		if end < 0 {
+			continue
+		}
+		for end < len(rs) && (isCJKTerminator(rs[end].r) || rs[end].r == '.' || rs[end].r == '!' || rs[end].r == '?' || isSentenceCloser(rs[end].r)) {
+			end++
+		}
+		emit(end)
+		i = end - 1
+	}
+	emit(len(rs))
+	return out
+}
+
+func isLatinSentenceEnd(rs []posRune, start, i int) bool {
+	// 跳过连续终止符与收尾引号，看后面是否是空白/段落结束
+	j := i + 1
+	for j < len(rs) && (rs[j].r == '.' || rs[j].r == '!' || rs[j].r == '?' || isSentenceCloser(rs[j].r)) {
+		j++
+	}
+	if j < len(rs) && !unicode.IsSpace(rs[j].r) && !isHanRune(rs[j].r) {
+		return false
+	}
+	if rs[i].r != '.' || j > i+1 {
+		return true
+	}
+	// 取 "." 前面的单词判断缩写
+	k := i
+	for k > start && !unicode.IsSpace(rs[k-1].r) {
+		k--
+	}
+	var word strings.Builder
+	for _, p := range rs[k:i] {
+		word.WriteRune(p.r)
+	}
+	orig := strings.TrimLeft(word.String(), "(\"'“‘")
+	w := strings.ToLower(orig)
+	if _, ok := sentenceAbbreviations[w]; ok {
+		return false
+	}
+	// 单个大写字母视为人名首字母，如 "J. Smith"；"plan a." 这样的小写单字母仍可结束句子
+	if wr := []rune(orig); len(wr) == 1 && unicode.IsUpper(wr[0]) {
+		return false
+	}
+	// 后面紧跟小写字母说明句子还没结束
+	for ; j < len(rs); j++ {
+		if !unicode.IsSpace(rs[j].r) {
+			return !unicode.IsLower(rs[j].r)
+		}
+	}
+	return true
+}
+
+func joinSentenceRunes(rs []posRune) string {
+	var b strings.Builder
+	for i := 0; i < len(rs); i++ {
+		if rs[i].r != '\n' {
+			b.WriteRune(rs[i].r)
+			continue
+		}
+		// 段内换行连同两侧缩进/空白合并：两侧都是中文时直接相连，否则视为一个空格
+		j := i + 1
+		for j < len(rs) && unicode.IsSpace(rs[j].r) {
+			j++
+		}
+		prev := strings.TrimRightFunc(b.String(), unicode.IsSpace)
+		b.Reset()
+		b.WriteString(prev)
+		last, _ := utf8.DecodeLastRuneInString(prev)
+		if j >= len(rs) || !(isHanOrCJKPunct(last) && isHanOrCJKPunct(rs[j].r)) {
+			b.WriteRune(' ')
+		}
+		i = j - 1
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// ComputeSegmentStats 汇总句子与段落统计，句长按字符（rune）计。
+func ComputeSegmentStats(lines []string) SegmentStats {
+	st := SegmentStats{Paragraphs: len(SplitParagraphs(lines))}
+	total := 0
+	for _, s := range SplitSentences(lines) {
+		st.Sentences++
+		total += s.Chars
+		if s.Chars > st.MaxSentenceLength {
+			st.MaxSentenceLength = s.Chars
+		}
+	}
+	if st.Sentences > 0 {
+		st.AvgSentenceLength = round2(float64(total) / float64(st.Sentences))
+	}
+	return st
+}
+
+func isCJKTerminator(r rune) bool {
+	return r == '。' || r == '！' || r == '？' || r == '…'
+}
+
+func isSentenceCloser(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '”', '’', '」', '』', '）', '》', '】':
+		return true
+	}
+	return false
+}
+
+func isHanRune(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+func isHanOrCJKPunct(r rune) bool {
+	return isHanRune(r) || (r >= 0x3000 && r <= 0x303F) || (r >= 0xFF00 && r <= 0xFFEF)
+}
@@ -0,0 +1,67 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitParagraphs(t *testing.T) {
+	lines := []string{"# 标题", "第一段", "第二行", "", "```", "code", "", "more code", "```", "第二段"}
+	ps := SplitParagraphs(lines)
+	if len(ps) != 2 {
+		t.Fatalf("expected two paragraphs, got: %+v", ps)
+	}
+	if ps[0].StartLine != 2 || ps[0].EndLine != 3 || ps[0].Lines != 2 || ps[0].Chars != 6 {
+		t.Fatalf("unexpected first paragraph: %+v", ps[0])
+	}
+	if ps[1].StartLine != 10 || ps[1].Text != "第二段" {
+		t.Fatalf("unexpected second paragraph: %+v", ps[1])
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	lines := []string{
+		"第一句话。第二句话！",
+		"第三句",
+		"跨行了？",
+		"",
+		"Dr. Smith met J. Doe at 3.14 p.m. today. It was fine!",
+		"He said \"hi.\" Then left... e.g. this one",
+	}
+	want := []struct {
+		line, col int
+		text      string
+	}{
+		{1, 1, "第一句话。"},
+		{1, 6, "第二句话！"},
+		{2, 1, "第三句跨行了？"},
+		{5, 1, "Dr. Smith met J. Doe at 3.14 p.m. today."},
+		{5, 42, "It was fine!"},
+		{6, 1, "He said \"hi.\""},
+		{6, 15, "Then left..."},
+		{6, 28, "e.g. this one"},
+	}
+	got := SplitSentences(lines)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d sentences, got: %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Line != w.line || got[i].Column != w.col || got[i].Text != w.text {
+			t.Fatalf("sentence %d: want %+v, got %+v", i, w, got[i])
+		}
+	}
+
+	st := ComputeSegmentStats(lines)
+	if st.Sentences != 8 || st.Paragraphs != 2 || st.MaxSentenceLength != 40 {
+		t.Fatalf("unexpected segment stats: %+v", st)
+	}
+
+	single := SplitSentences([]string{"I saw it. Then I left.", "It was plan a. Next we go."})
+	texts := make([]string, 0, len(single))
+	for _, s := range single {
+		texts = append(texts, s.Text)
+	}
+	if strings.Join(texts, "|") != "I saw it.|Then I left.|It was plan a.|Next we go." {
+		t.Fatalf("lowercase one-letter words should end sentences: %q", texts)
+	}
+}
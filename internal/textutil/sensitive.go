@@ -0,0 +1,287 @@
+package textutil
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMinEntropy 是 secrets.high_entropy 的默认香农熵下限（比特/字符）。随机 base64 串约 4.3~5，
+	// 英文单词拼成的标识符与十六进制哈希通常低于 4。
+	DefaultMinEntropy = 4.0
+	// DefaultMinEntropyLength 是参与高熵检测的最短 token 长度。
+	DefaultMinEntropyLength = 24
+)
+
+// SensitiveMatch 是一处疑似敏感信息，Start/End 为字节偏移，各条匹配互不重叠。
+type SensitiveMatch struct {
+	RuleID string
+	Start  int
+	End    int
+}
+
+// SensitiveOptions 控制 FindSensitive 检查哪些规则组。
+type SensitiveOptions struct {
+	Secrets          bool
+	PII              bool
+	Exclude          []string // 不检查的子规则 ID
+	MinEntropy       float64  // 为 0 时用 DefaultMinEntropy
+	MinEntropyLength int      // 为 0 时用 DefaultMinEntropyLength
+}
+
+type sensitiveDetector struct {
+	ID    string
+	Label string
+	re    *regexp.Regexp
+	// bounded 为 true 时要求匹配前后不是字母或数字，避免从更长的数字串中截取。
+	bounded bool
+	valid   func(string) bool
+}
+
+var secretDetectors = []sensitiveDetector{
+	{ID: "secrets.private_key", Label: "私钥", re: regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----(?:[\s\S]*?-----END (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----)?`)},
+	{ID: "secrets.aws_access_key", Label: "AWS Access Key", re: regexp.MustCompile(`(?:AKIA|ASIA)[0-9A-Z]{16}`), bounded: true},
+	{ID: "secrets.github_token", Label: "GitHub Token", re: regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,}`), bounded: true},
+	{ID: "secrets.slack_token", Label: "Slack Token", re: regexp.MustCompile(`xox[abposr]-[A-Za-z0-9-]{10,}`), bounded: true},
+	{ID: "secrets.google_api_key", Label: "Google API Key", re: regexp.MustCompile(`AIza[0-9A-Za-z_-]{35}`), bounded: true},
+	{ID: "secrets.stripe_key", Label: "Stripe Key", re: regexp.MustCompile(`[sr]k_(?:live|test)_[0-9A-Za-z]{16,}`), bounded: true},
+	{ID: "secrets.llm_api_key", Label: "LLM API Key", re: regexp.MustCompile(`sk-(?:ant-|proj-)?[A-Za-z0-9_-]{20,}`), bounded: true},
+	{ID: "secrets.jwt", Label: "JWT", re: regexp.MustCompile(`eyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{10,}`), bounded: true},
+}
+
+var piiDetectors = []sensitiveDetector{
+	{ID: "pii.cn_id_card", Label: "身份证号", re: regexp.MustCompile(`[1-9]\d{16}[\dXx]`), bounded: true, valid: ValidCNIDCard},
+	{ID: "pii.bank_card", Label: "银行卡号", re: regexp.MustCompile(`[3-6]\d{3}(?:[ -]?\d{4}){2}[ -]?\d{4,7}`), bounded: true, valid: func(s string) bool {
+		d := digitsOnly(s)
+		return len(d) >= 16 && len(d) <= 19 && LuhnValid(d)
+	}},
+	{ID: "pii.cn_mobile", Label: "手机号", re: regexp.MustCompile(`(?:\+?86[- ]?)?1[3-9]\d{9}`), bounded: true},
+	{ID: "pii.email", Label: "邮箱地址", re: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)},
+}
+
+const highEntropyID = "secrets.high_entropy"
+
+var entropyTokenRe = regexp.MustCompile(`[A-Za-z0-9+/=_-]+`)
+
+// SensitiveRuleIDs 返回规则组（secrets 或 pii）下的全部子规则 ID。
+func SensitiveRuleIDs(group string) []string {
+	out := make([]string, 0)
+	switch group {
+	case "secrets":
+		for _, d := range secretDetectors {
+			out = append(out, d.ID)
+		}
+		out = append(out, highEntropyID)
+	case "pii":
+		for _, d := range piiDetectors {
+			out = append(out, d.ID)
+		}
+	}
+	return out
+}
+
+// SensitiveLabel 返回子规则的中文说明，用于违规消息。
+func SensitiveLabel(id string) string {
+	if id == highEntropyID {
+		return "高熵字符串"
+	}
+	for _, ds := range [][]sensitiveDetector{secretDetectors, piiDetectors} {
+		for _, d := range ds {
+			if d.ID == id {
+				return d.Label
+			}
+		}
+	}
+	return id
+}
+
+// FindSensitive 找出文本中的密钥与个人信息，按位置排序。多条规则命中同一段文本时，
+// 先列出的规则优先（如身份证号不会再报银行卡号），高熵检测只看其他规则没有覆盖的 token。
+func FindSensitive(text string, opts SensitiveOptions) []SensitiveMatch {
+	excluded := map[string]bool{}
+	for _, id := range opts.Exclude {
+		excluded[id] = true
+	}
+	detectors := make([]sensitiveDetector, 0)
+	if opts.Secrets {
+		detectors = append(detectors, secretDetectors...)
+	}
+	if opts.PII {
+		detectors = append(detectors, piiDetectors...)
+	}
+
+	out := make([]SensitiveMatch, 0)
+	overlaps := func(start, end int) bool {
+		for _, m := range out {
+			if start < m.End && m.Start < end {
+				return true
+			}
+		}
+		return false
+	}
+	for _, d := range detectors {
+		if excluded[d.ID] {
+			continue
+		}
+		for _, loc := range d.re.FindAllStringIndex(text, -1) {
+			s, e := loc[0], loc[1]
+			if d.bounded && (alnumBefore(text, s) || alnumAfter(text, e)) {
+				continue
+			}
+			if d.valid != nil && !d.valid(text[s:e]) {
+				continue
+			}
+			if overlaps(s, e) {
+				continue
+			}
+			out = append(out, SensitiveMatch{RuleID: d.ID, Start: s, End: e})
+		}
+	}
+
+	if opts.Secrets && !excluded[highEntropyID] {
+		minEntropy, minLen := opts.MinEntropy, opts.MinEntropyLength
+		if minEntropy <= 0 {
+			minEntropy = DefaultMinEntropy
+		}
+		if minLen <= 0 {
+			minLen = DefaultMinEntropyLength
+		}
+		for _, loc := range entropyTokenRe.FindAllStringIndex(text, -1) {
+			s, e := loc[0], loc[1]
+			tok := text[s:e]
+			if e-s < minLen || !strings.ContainsAny(tok, "0123456789") || !hasASCIILetter(tok) {
+				continue
+			}
+			if shannonEntropy(tok) < minEntropy || overlaps(s, e) {
+				continue
+			}
+			out = append(out, SensitiveMatch{RuleID: highEntropyID, Start: s, End: e})
+		}
+	}
+
+	sort.SliceStable(out, func(a, b int) bool { return out[a].Start < out[b].Start })
+	return out
+}
+
+// MaskSensitive 返回可以安全输出的遮盖值：私钥只保留 BEGIN 行，邮箱只遮盖用户名，
+// 其余保留首尾各至多 4 个字符（不超过总长的四分之一），中间用 * 代替。
+func MaskSensitive(id, value string) string {
+	switch id {
+	case "secrets.private_key":
+		if i := strings.IndexAny(value, "\r\n"); i >= 0 {
+			value = value[:i]
+		}
+		return value + "…"
+	case "pii.email":
+		if at := strings.LastIndex(value, "@"); at > 0 {
+			r, size := utf8.DecodeRuneInString(value)
+			return string(r) + strings.Repeat("*", utf8.RuneCountInString(value[size:at])) + value[at:]
+		}
+	}
+	runes := []rune(value)
+	keep := len(runes) / 4
+	if keep > 4 {
+		keep = 4
+	}
+	return string(runes[:keep]) + strings.Repeat("*", len(runes)-2*keep) + string(runes[len(runes)-keep:])
+}
+
+// LuhnValid 按 Luhn 算法校验纯数字串（银行卡号）。
+func LuhnValid(digits string) bool {
+	if digits == "" {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		c := digits[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		n := int(c - '0')
+		if double {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+var idCardWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+
+// ValidCNIDCard 校验 18 位居民身份证号：出生日期中的月、日在合法范围内，且末位校验码正确。
+func ValidCNIDCard(id string) bool {
+	if len(id) != 18 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		c := id[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		sum += int(c-'0') * idCardWeights[i]
+	}
+	month := int(id[10]-'0')*10 + int(id[11]-'0')
+	day := int(id[12]-'0')*10 + int(id[13]-'0')
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return false
+	}
+	check := "10X98765432"[sum%11]
+	last := id[17]
+	if last == 'x' {
+		last = 'X'
+	}
+	return last == check
+}
+
+func shannonEntropy(s string) float64 {
+	counts := map[rune]int{}
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	h := 0.0
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+func digitsOnly(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func hasASCIILetter(s string) bool {
+	return strings.IndexFunc(s, isASCIILetter) >= 0
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isASCIIAlnum(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func alnumBefore(text string, i int) bool {
+	return i > 0 && isASCIIAlnum(text[i-1])
+}
+
+func alnumAfter(text string, i int) bool {
+	return i < len(text) && isASCIIAlnum(text[i])
+}
@@ -7,7 +7,6 @@ import (
 	"regexp"
 	"sort"
 	"strings"
-	"unicode"
 	"unicode/utf8"
 
 	"github.com/mattn/go-runewidth"
@@ -134,35 +133,13 @@ func DisplayWidth(s string) int {
 	return col
 }
 
+// GuessLanguage 返回 DetectLanguages 得分最高且占比不低于 0.4 的语言，否则为 unknown。
 func GuessLanguage(s string) string {
-	if s == "" {
+	cands := DetectLanguages(s, 1)
+	if len(cands) == 0 || cands[0].Score < 0.4 {
 		return "unknown"
 	}
-	han := 0
-	alpha := 0
-	runes := 0
-	for _, r := range s {
-		if unicode.IsSpace(r) {
-			continue
-		}
-		runes++
-		if unicode.Is(unicode.Han, r) {
-			han++
-		}
-		if r <= unicode.MaxASCII && unicode.IsLetter(r) {
-			alpha++
-		}
-	}
-	if runes == 0 {
-		return "unknown"
-	}
-	if float64(han)/float64(runes) > 0.20 {
-		return "zh"
-	}
-	if float64(alpha)/float64(runes) > 0.40 {
-		return "en"
-	}
-	return "unknown"
+	return cands[0].Lang
 }
 
 func detectLineEnding(s string) string {
@@ -0,0 +1,190 @@
+package textutil
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WordMatcher 是按 rune 构建的 Aho–Corasick 自动机，一次扫描即可找出大词表中所有词的出现位置。
+// 构建后只读，可在多个 goroutine 间共享。
+type WordMatcher struct {
+	words     []string
+	lens      []int // 每个词的 rune 数
+	nodes     []acNode
+	foldCase  bool
+	wholeWord bool
+}
+
+type acNode struct {
+	next map[rune]int32
+	fail int32
+	out  []int32 // 在此节点结束的词（已合并 fail 链上的输出）
+}
+
+// WordMatch 是一次命中，[Start, End) 为字节偏移，Word 为词在词表中的下标。
+type WordMatch struct {
+	Start int
+	End   int
+	Word  int
+}
+
+// NewWordMatcher 用词表构建自动机。caseSensitive 为 false 时按小写比较；
+// wholeWord 为 true 时，词的首尾若是拉丁字母/数字，相邻字符不能再是字母、数字或下划线（汉字不受限制）。
+func NewWordMatcher(words []string, caseSensitive, wholeWord bool) *WordMatcher {
+	m := &WordMatcher{
+		words:     words,
+		lens:      make([]int, len(words)),
+		nodes:     []acNode{{}},
+		foldCase:  !caseSensitive,
+		wholeWord: wholeWord,
+	}
+	for i, w := range words {
+		cur := int32(0)
+		for _, r := range w {
+			r = m.fold(r)
+			nxt, ok := m.nodes[cur].next[r]
+			if !ok {
+				if m.nodes[cur].next == nil {
+					m.nodes[cur].next = map[rune]int32{}
+				}
+				m.nodes = append(m.nodes, acNode{})
+				nxt = int32(len(m.nodes) - 1)
+				m.nodes[cur].next[r] = nxt
+			}
+			cur = nxt
+			m.lens[i]++
+		}
+		if m.lens[i] > 0 {
+			m.nodes[cur].out = append(m.nodes[cur].out, int32(i))
+		}
+	}
+
+	queue := make([]int32, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].next {
+			f := m.nodes[cur].fail
+			for f > 0 {
+				if _, ok := m.nodes[f].next[r]; ok {
+					break
+				}
+				f = m.nodes[f].fail
+			}
+			if nxt, ok := m.nodes[f].next[r]; ok && nxt != child {
+				m.nodes[child].fail = nxt
+			}
+			fo := m.nodes[m.nodes[child].fail].out
+			if len(fo) > 0 {
+				m.nodes[child].out = append(m.nodes[child].out, fo...)
+			}
+			queue = append(queue, child)
+		}
+	}
+	return m
+}
+
+// Words 返回构建时的词表。
+func (m *WordMatcher) Words() []string {
+	return m.words
+}
+
+func (m *WordMatcher) fold(r rune) rune {
+	if m.foldCase {
+		return unicode.ToLower(r)
+	}
+	return r
+}
+
+// scan 找出所有命中（可能重叠），按结束位置先后回调。
+func (m *WordMatcher) scan(s string, fn func(WordMatch)) {
+	starts := make([]int, 0, len(s))
+	cur := int32(0)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		starts = append(starts, i)
+		end := i + size
+		r = m.fold(r)
+		for {
+			if nxt, ok := m.nodes[cur].next[r]; ok {
+				cur = nxt
+				break
+			}
+			if cur == 0 {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		for _, w := range m.nodes[cur].out {
+			start := starts[len(starts)-m.lens[w]]
+			if m.wholeWord && !AtWordBoundary(s, start, end) {
+				continue
+			}
+			fn(WordMatch{Start: start, End: end, Word: int(w)})
+		}
+		i = end
+	}
+}
+
+// FindAll 返回互不重叠的命中：从左到右，同一起点取最长的词。
+func (m *WordMatcher) FindAll(s string) []WordMatch {
+	all := make([]WordMatch, 0)
+	m.scan(s, func(wm WordMatch) { all = append(all, wm) })
+	sort.SliceStable(all, func(a, b int) bool {
+		if all[a].Start != all[b].Start {
+			return all[a].Start < all[b].Start
+		}
+		return all[a].End > all[b].End
+	})
+	out := make([]WordMatch, 0, len(all))
+	last := 0
+	for _, wm := range all {
+		if wm.Start < last {
+			continue
+		}
+		out = append(out, wm)
+		last = wm.End
+	}
+	return out
+}
+
+// Present 返回每个词是否在 s 中出现过。
+func (m *WordMatcher) Present(s string) []bool {
+	found := make([]bool, len(m.words))
+	m.scan(s, func(wm WordMatch) { found[wm.Word] = true })
+	return found
+}
+
+// AtWordBoundary 检查 s[start:end] 两端：首尾是词字符时，相邻字符不能也是词字符。
+func AtWordBoundary(s string, start, end int) bool {
+	first, _ := utf8.DecodeRuneInString(s[start:])
+	if IsWordRune(first) && start > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(s[:start])
+		if IsWordRune(prev) {
+			return false
+		}
+	}
+	last, _ := utf8.DecodeLastRuneInString(s[:end])
+	if IsWordRune(last) && end < len(s) {
+		next, _ := utf8.DecodeRuneInString(s[end:])
+		if IsWordRune(next) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsWordRune 判断是否为需要词边界的字符：字母、数字与下划线，但不含汉字、假名与谚文。
+func IsWordRune(r rune) bool {
+	if r == '_' || unicode.IsDigit(r) {
+		return true
+	}
+	if !unicode.IsLetter(r) {
+		return false
+	}
+	return !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,53 @@
+package textutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWordMatcher(t *testing.T) {
+	m := NewWordMatcher([]string{"赌博", "赌", "he", "she", "hers", "Cat"}, true, false)
+	s := "ushers 去赌博，Cat 与 cat"
+	got := m.FindAll(s)
+	want := []string{"she", "赌博", "Cat"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected matches: %+v", got)
+	}
+	for i, w := range want {
+		if m.Words()[got[i].Word] != w || s[got[i].Start:got[i].End] != w {
+			t.Fatalf("match %d: want %q, got %+v", i, w, got[i])
+		}
+	}
+	present := m.Present(s)
+	for i, w := range []bool{true, true, true, true, true, true} {
+		if present[i] != w {
+			t.Fatalf("present %d: want %v, got %v", i, w, present)
+		}
+	}
+
+	fold := NewWordMatcher([]string{"cat", "log in"}, false, true)
+	got = fold.FindAll("CAT catalog Log In, cat_1 （cat）猫cat")
+	if len(got) != 4 {
+		t.Fatalf("unexpected whole word matches: %+v", got)
+	}
+	if got[0].Start != 0 || got[1].Start != 12 || got[3].End != len("CAT catalog Log In, cat_1 （cat）猫cat") {
+		t.Fatalf("unexpected whole word offsets: %+v", got)
+	}
+	if p := fold.Present("catalog"); p[0] || p[1] {
+		t.Fatalf("whole word should not match inside words: %v", p)
+	}
+}
+
+func TestWordMatcherManyWords(t *testing.T) {
+	words := make([]string, 0, 3000)
+	for i := 0; i < 3000; i++ {
+		words = append(words, fmt.Sprintf("词%04d", i))
+	}
+	m := NewWordMatcher(words, true, false)
+	text := strings.Repeat("正文", 100) + "词2999 和 词0001"
+	got := m.FindAll(text)
+	if len(got) != 2 || got[0].Word != 2999 || got[1].Word != 1 {
+		t.Fatalf("unexpected matches: %+v", got)
+	}
+}
@@ -0,0 +1,94 @@
+package textutil
+
+import (
+	"strings"
+)
+
+const (
+	ZhHans = "zh-Hans"
+	ZhHant = "zh-Hant"
+)
+
+type ChineseVariant struct {
+	Variant    string
+	HansChars  int
+	HantChars  int
+	MixedRatio float64
+}
+
+var (
+	simpToTrad = map[rune][]rune{}
+	tradToSimp = map[rune]rune{}
+)
+
+func init() {
+	for _, entry := range strings.Fields(zhVariantPairs) {
+		rs := []rune(entry)
+		simpToTrad[rs[0]] = rs[1:]
+		for _, t := range rs[1:] {
+			tradToSimp[t] = rs[0]
+		}
+	}
+	for _, entry := range strings.Fields(zhTradOnly) {
+		rs := []rune(entry)
+		for _, t := range rs[1:] {
+			tradToSimp[t] = rs[0]
+		}
+	}
+}
+
+// ZhVariantOf 返回只在简体或只在繁体中使用的字所属的体系；两边通用的字返回空串。
+func ZhVariantOf(r rune) string {
+	if _, ok := simpToTrad[r]; ok {
+		return ZhHans
+	}
+	if _, ok := tradToSimp[r]; ok {
+		return ZhHant
+	}
+	return ""
+}
+
+// ZhCounterparts 返回字在另一体系中的写法（繁体→简体只有一个，简体→繁体可能有多个）。
+func ZhCounterparts(r rune) []string {
+	if ts, ok := simpToTrad[r]; ok {
+		out := make([]string, 0, len(ts))
+		for _, t := range ts {
+			out = append(out, string(t))
+		}
+		return out
+	}
+	if s, ok := tradToSimp[r]; ok {
+		return []string{string(s)}
+	}
+	return nil
+}
+
+// DetectChineseVariant 统计简体/繁体特征字数量，按多数判定体系；两者相等时为 mixed。
+// MixedRatio 为少数一方占特征字总数的比例。
+func DetectChineseVariant(s string) ChineseVariant {
+	cv := ChineseVariant{Variant: "unknown"}
+	for _, r := range s {
+		switch ZhVariantOf(r) {
+		case ZhHans:
+			cv.HansChars++
+		case ZhHant:
+			cv.HantChars++
+		}
+	}
+	total := cv.HansChars + cv.HantChars
+	if total == 0 {
+		return cv
+	}
+	minority := cv.HansChars
+	switch {
+	case cv.HansChars > cv.HantChars:
+		cv.Variant = ZhHans
+		minority = cv.HantChars
+	case cv.HantChars > cv.HansChars:
+		cv.Variant = ZhHant
+	default:
+		cv.Variant = "mixed"
+	}
+	cv.MixedRatio = float64(minority) / float64(total)
+	return cv
+}
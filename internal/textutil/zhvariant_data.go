@@ -0,0 +1,44 @@
+package textutil
+
+// zhVariantPairs 每项首字为简体字，其余为对应的繁体字；两边都只在各自的书写体系中使用。
+const zhVariantPairs = `
+这這 个個 们們 来來 时時 会會 说說 国國 为為爲 对對 学學 发發髮 经經 过過 还還 进進 动動 种種 样樣 从從 现現
+实實 点點 长長 开開 关關 问問 题題 间間 边邊 电電 话話 书書 见見 气氣 车車 东東 马馬 门門 鱼魚 鸟鳥 龙龍
+风風 飞飛 业業 两兩 与與 严嚴 丰豐 临臨 丽麗 举舉 么麼 义義 乌烏 乐樂 乔喬 习習 乡鄉 买買 乱亂 争爭 亏虧
+亚亞 产產 亩畝 亲親 亿億 仅僅 仓倉 仪儀 价價 众眾 优優 伞傘 伟偉 传傳 伤傷 伦倫 伪偽 体體 佣傭 侠俠 侣侶
+侦偵 侧側 侨僑 俭儉 债債 倾傾 偿償 储儲 儿兒 兑兌 党黨 兰蘭 兴興 养養 兽獸 冈岡 册冊 写寫 军軍 农農 冯馮
+决決 况況 冻凍 净淨 凉涼 减減 凤鳳 凭憑 凯凱 击擊 刘劉 则則 刚剛 创創 删刪 别別 剂劑 剧劇 劝勸 办辦 务務
+励勵 劲勁 劳勞 势勢 勋勳 匀勻 区區 医醫 华華 协協 单單 卖賣 卢盧 卫衛衞 却卻 厂廠 厅廳 历歷曆 压壓 厌厭
+厕廁 厢廂 厦廈 县縣 参參 双雙 变變 叙敘 号號 叹嘆 吓嚇 吕呂 吗嗎 启啟啓 吴吳 员員 呜嗚 咏詠 响響 哑啞 哗嘩
+唤喚 喷噴 团團 园園 围圍 图圖 圆圓 圣聖 场場 坏壞 块塊 坚堅 坛壇 坝壩 坟墳 坠墜 垄壟 垒壘 执執 报報 墙牆
+壮壯 声聲 壳殼 处處 备備 复復複 够夠 头頭 夹夾 夺奪 奋奮 奖獎 妆妝 妇婦 妈媽 娄婁 娱娛 婴嬰 孙孫 宁寧 宝寶
+宠寵 审審 宪憲 宾賓 寻尋 导導 寿壽 将將 尔爾 尘塵 尝嘗 层層 属屬 岁歲 岂豈 岗崗 岛島 峡峽 币幣 帅帥 师師
+帐帳 带帶 帮幫 广廣 庄莊 庆慶 库庫 应應 废廢 异異 弃棄 张張 弯彎 弹彈 强強 归歸 当當 录錄 彦彥 彻徹 径徑
+忆憶 忧憂 怀懷 态態 怜憐 总總 恋戀 恶惡 恳懇 悦悅 惊驚 惧懼 惨慘 惯慣 愤憤 愿願 戏戲 战戰 户戶 扩擴 扫掃
+扬揚 扰擾 抚撫 抢搶 护護 担擔 拟擬 拢攏 拥擁 择擇 挂掛 挡擋 挤擠 挥揮 捞撈 损損 换換 据據 掷擲 摄攝 摆擺
+摇搖 撑撐 敌敵 数數 斋齋 断斷 无無 旧舊 旷曠 显顯 晋晉 晒曬 晓曉 暂暫 术術 机機 杀殺 杂雜 权權 条條 杨楊
+极極 构構 枪槍 柜櫃 标標 栋棟 树樹 档檔 桥橋 梦夢 检檢 楼樓 横橫 欢歡 欧歐 残殘 毁毀 毕畢 汇匯 汉漢 汤湯
+沟溝 没沒 沪滬 泪淚 泽澤 洁潔 洒灑 浅淺 测測 济濟 浓濃 涛濤 润潤 涨漲 渐漸 温溫 湾灣 湿濕 满滿 滚滾 灭滅
+灯燈 灵靈 灾災 炉爐 炼煉 烂爛 热熱 焕煥 爱愛 爷爺 犹猶 狮獅 独獨 狭狹 猎獵 猪豬 献獻 环環 玛瑪 画畫 畅暢
+疗療 疯瘋 痒癢 皱皺 监監 盖蓋 盘盤 睁睜 矿礦 码碼 砖磚 础礎 确確 礼禮 祸禍 离離 积積 称稱 稳穩 穷窮 窃竊
+竞競 笔筆 笼籠 筑築 签簽籤 简簡 粮糧 类類 紧緊 纪紀 约約 级級 纯純 纲綱 纳納 纸紙 线線 练練 组組 细細 织織
+终終 结結 绕繞 给給 绝絕 统統 继繼 绩績 续續 维維 综綜 绿綠 编編 缘緣 缩縮 缓緩 络絡 纽紐 绍紹 绘繪 网網
+罗羅 罚罰 罢罷 职職 联聯 肃肅 肠腸 肤膚 胁脅 胜勝 脑腦 脏臟髒 脚腳 脱脫 腾騰 舰艦 艺藝 节節 苏蘇 苹蘋 荣榮
+药藥 获獲穫 莱萊 营營 萝蘿 虑慮 虫蟲 虽雖 蚀蝕 蛮蠻 补補 衬襯 袭襲 装裝 状狀 观觀 规規 视視 览覽 觉覺 誉譽
+计計 订訂 认認 讨討 让讓 训訓 议議 讯訊 记記 讲講 许許 论論 设設 访訪 证證 评評 识識 诉訴 词詞 译譯 试試
+诗詩 诚誠 询詢 该該 详詳 语語 误誤 请請 诸諸 读讀 课課 谁誰 调調 谈談 谢謝 谱譜 贝貝 负負 贡貢 财財 责責
+贤賢 败敗 货貨 质質 购購 贯貫 贵貴 费費 贸貿 资資 赏賞 赔賠 赛賽 赞贊 账賬 赶趕 赵趙 趋趨 跃躍 践踐 踪蹤
+轨軌 转轉 轮輪 软軟 轻輕 载載 较較 辅輔 辆輛 辈輩 辉輝 输輸 辞辭 达達 迁遷 运運 远遠 违違 连連 迟遲 适適
+选選 递遞 逻邏 遗遺 邓鄧 邮郵 邻鄰 郑鄭 酱醬 释釋 税稅 锐銳 针針 钟鐘鍾 钢鋼 钱錢 铁鐵 铃鈴 银銀 铺鋪 链鏈
+销銷 锁鎖 错錯 锅鍋 键鍵 镇鎮 镜鏡 闪閃 闭閉 闯闖 闲閒 闹鬧 闻聞 阅閱 阔闊 队隊 阳陽 阴陰 阵陣 阶階 际際
+陆陸 陈陳 险險 随隨 隐隱 难難 雾霧 静靜 韩韓 页頁 顶頂 项項 顺順 须須鬚 顾顧 顿頓 预預 领領 频頻 颜顏 额額
+饭飯 饮飲 饰飾 饱飽 饿餓 馆館 驱驅 驶駛 驻駐 验驗 骂罵 骑騎 骗騙 鸡雞 鸭鴨 麦麥 黄黃 齐齊 齿齒 龟龜 专專
+丝絲 丧喪 鉴鑒 岭嶺 壶壺 内內
+`
+
+// zhTradOnly 每项首字为简体写法（该字在繁体中同样常用，不能据此判断为简体），
+// 其余为只在繁体中使用的字。
+const zhTradOnly = `
+后後 里裡裏 干幹 只隻 台臺颱檯 云雲 松鬆 谷穀 丑醜 斗鬥 几幾 征徵 系係繫 卷捲 制製 范範 于於 面麵 板闆 伙夥
+冲衝 咸鹹 游遊 郁鬱 余餘 致緻 朴樸 才纔 表錶
+`
@@ -0,0 +1,74 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestZhVariantTablesAreDisjoint(t *testing.T) {
+	for r := range simpToTrad {
+		if _, ok := tradToSimp[r]; ok {
+			t.Fatalf("%q is listed as both simplified-only and traditional-only", r)
+		}
+	}
+	for _, entry := range strings.Fields(zhVariantPairs + zhTradOnly) {
+		if len([]rune(entry)) < 2 {
+			t.Fatalf("malformed table entry: %q", entry)
+		}
+	}
+}
+
+func TestDetectChineseVariant(t *testing.T) {
+	hans := DetectChineseVariant("这是一个简体中文的说明文档。")
+	if hans.Variant != ZhHans || hans.HantChars != 0 || hans.MixedRatio != 0 {
+		t.Fatalf("unexpected hans detection: %+v", hans)
+	}
+	hant := DetectChineseVariant("這是一個繁體中文的說明文檔。")
+	if hant.Variant != ZhHant || hant.HansChars != 0 {
+		t.Fatalf("unexpected hant detection: %+v", hant)
+	}
+	mixed := DetectChineseVariant("这是一个说明，這個")
+	if mixed.Variant != ZhHans || mixed.HansChars != 3 || mixed.HantChars != 2 {
+		t.Fatalf("unexpected mixed detection: %+v", mixed)
+	}
+	if mixed.MixedRatio != 0.4 {
+		t.Fatalf("unexpected mixed ratio: %v", mixed.MixedRatio)
+	}
+	if DetectChineseVariant("hello 中文").Variant != "unknown" {
+		t.Fatalf("text without variant-specific chars should be unknown")
+	}
+}
+
+func TestZhCounterparts(t *testing.T) {
+	if got := ZhCounterparts('发'); len(got) != 2 || got[0] != "發" || got[1] != "髮" {
+		t.Fatalf("unexpected counterparts for 发: %v", got)
+	}
+	if got := ZhCounterparts('後'); len(got) != 1 || got[0] != "后" {
+		t.Fatalf("unexpected counterparts for 後: %v", got)
+	}
+	if ZhVariantOf('后') != "" || ZhVariantOf('中') != "" {
+		t.Fatalf("shared characters should not belong to a variant")
+	}
+}
+
+func TestZhSimplifiedOnlyCommonChars(t *testing.T) {
+	for _, c := range []struct {
+		simp rune
+		trad []string
+	}{{'为', []string{"為", "爲"}}, {'内', []string{"內"}}, {'签', []string{"簽", "籤"}}} {
+		if ZhVariantOf(c.simp) != ZhHans {
+			t.Fatalf("%q is not used in Traditional text and should count as simplified", c.simp)
+		}
+		if got := ZhCounterparts(c.simp); strings.Join(got, "") != strings.Join(c.trad, "") {
+			t.Fatalf("unexpected counterparts for %q: %v", c.simp, got)
+		}
+		for _, tr := range c.trad {
+			if ZhVariantOf([]rune(tr)[0]) != ZhHant {
+				t.Fatalf("%q should count as traditional", tr)
+			}
+		}
+	}
+	if got := DetectChineseVariant("這個內容為簽名說明，内容为签名"); got.HansChars != 3 {
+		t.Fatalf("为/内/签 in a zh-Hant text should be flagged: %+v", got)
+	}
+}